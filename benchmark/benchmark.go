@@ -0,0 +1,209 @@
+package benchmark
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"proj3-redesigned/configspace"
+	"proj3-redesigned/planner"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Case is a configuration space planned with a fixed number of samples
+type Case struct {
+	Config  string // Configuration space file
+	Samples int    // Samples drawn in each run
+}
+
+// Matrix describes the runs of a benchmark. Every case is planned
+// sequentially as the baseline, then with each parallel strategy at each
+// thread count
+type Matrix struct {
+	Cases      []Case    // Configuration spaces and sample sizes
+	Strategies []string  // Parallel strategies, ws or bsp
+	Threads    []int     // Thread counts of the parallel strategies
+	Warmup     int       // Untimed runs before each measurement
+	Reps       int       // Timed runs of each measurement
+	Seed       int64     // Sampler seed, random when zero
+	Progress   func(Row) // Called as each measurement finishes, may be nil
+}
+
+// Row is the measurement of a case with one strategy and thread count
+type Row struct {
+	Config     string          // Configuration space file
+	Samples    int             // Samples drawn in each run
+	Strategy   string          // sequential, ws or bsp
+	Threads    int             // Threads used, 1 for sequential
+	Times      []time.Duration // Time of each timed run
+	Median     time.Duration   // Median time
+	Stddev     time.Duration   // Sample standard deviation of the times
+	Speedup    float64         // Sequential median over this median
+	Efficiency float64         // Speedup per thread
+}
+
+// Run the benchmark matrix, the context's error is returned along with the
+// rows measured so far if it is cancelled
+func Run(ctx context.Context, m Matrix) ([]Row, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for _, c := range m.Cases {
+		config := configspace.NewConfigSpace(c.Config)
+
+		// Sequential baseline
+		baseline, err := m.measure(ctx, config, c, "sequential", 1)
+		if err != nil {
+			return rows, err
+		}
+		baseline.Speedup, baseline.Efficiency = 1, 1
+		rows = append(rows, m.report(baseline))
+
+		for _, strategy := range m.Strategies {
+			for _, threads := range m.Threads {
+				row, err := m.measure(ctx, config, c, strategy, threads)
+				if err != nil {
+					return rows, err
+				}
+				row.Speedup = float64(baseline.Median) / float64(row.Median)
+				row.Efficiency = row.Speedup / float64(threads)
+				rows = append(rows, m.report(row))
+			}
+		}
+	}
+	return rows, nil
+}
+
+// Check the matrix before any run
+func (m Matrix) validate() error {
+	if len(m.Cases) == 0 {
+		return fmt.Errorf("no cases to run")
+	}
+	for _, c := range m.Cases {
+		if _, err := os.Stat(c.Config); err != nil {
+			return err
+		}
+		if c.Samples <= 0 {
+			return fmt.Errorf("samples must be positive, got %d", c.Samples)
+		}
+	}
+	for _, strategy := range m.Strategies {
+		if strategy != "ws" && strategy != "bsp" {
+			return fmt.Errorf("unknown strategy %q, expected ws or bsp", strategy)
+		}
+	}
+	for _, threads := range m.Threads {
+		if threads < 2 {
+			return fmt.Errorf("parallel strategies need at least 2 threads, got %d", threads)
+		}
+	}
+	if len(m.Strategies) > 0 && len(m.Threads) == 0 {
+		return fmt.Errorf("no thread counts for the parallel strategies")
+	}
+	if m.Warmup < 0 {
+		return fmt.Errorf("warmup must not be negative, got %d", m.Warmup)
+	}
+	if m.Reps < 1 {
+		return fmt.Errorf("reps must be positive, got %d", m.Reps)
+	}
+	return nil
+}
+
+// Time the warmup and repetitions of a case with one strategy
+func (m Matrix) measure(ctx context.Context, config *configspace.Config, c Case, strategy string,
+	threads int,
+) (Row, error) {
+	row := Row{Config: c.Config, Samples: c.Samples, Strategy: strategy, Threads: threads}
+	opts := planner.Options{Samples: c.Samples, Strategy: strategy, Threads: threads, Seed: m.Seed}
+	for i := 0; i < m.Warmup+m.Reps; i++ {
+		result, err := planner.Plan(ctx, config, opts)
+		if err != nil {
+			return row, err
+		}
+		if i >= m.Warmup {
+			row.Times = append(row.Times, result.Elapsed)
+		}
+	}
+	row.Median, row.Stddev = median(row.Times), stddev(row.Times)
+	return row, nil
+}
+
+// Pass a finished row to the progress callback
+func (m Matrix) report(row Row) Row {
+	if m.Progress != nil {
+		m.Progress(row)
+	}
+	return row
+}
+
+// Get the median of a list of times
+func median(times []time.Duration) time.Duration {
+	sorted := append([]time.Duration{}, times...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// Get the sample standard deviation of a list of times, zero for a single time
+func stddev(times []time.Duration) time.Duration {
+	n := len(times)
+	if n < 2 {
+		return 0
+	}
+	var mean float64
+	for _, t := range times {
+		mean += float64(t) / float64(n)
+	}
+	var sq float64
+	for _, t := range times {
+		sq += math.Pow(float64(t)-mean, 2)
+	}
+	return time.Duration(math.Sqrt(sq / float64(n-1)))
+}
+
+// WriteCSV writes the rows with a column for each timed run, times are in
+// seconds
+func WriteCSV(w io.Writer, rows []Row) error {
+	reps := 0
+	for _, row := range rows {
+		if len(row.Times) > reps {
+			reps = len(row.Times)
+		}
+	}
+	header := []string{"config", "samples", "strategy", "threads", "median_s", "stddev_s",
+		"speedup", "efficiency"}
+	for i := 1; i <= reps; i++ {
+		header = append(header, fmt.Sprintf("rep%d_s", i))
+	}
+
+	out := csv.NewWriter(w)
+	out.Write(header)
+	seconds := func(t time.Duration) string { return strconv.FormatFloat(t.Seconds(), 'f', 4, 64) }
+	for _, row := range rows {
+		record := []string{
+			row.Config,
+			strconv.Itoa(row.Samples),
+			row.Strategy,
+			strconv.Itoa(row.Threads),
+			seconds(row.Median),
+			seconds(row.Stddev),
+			strconv.FormatFloat(row.Speedup, 'f', 3, 64),
+			strconv.FormatFloat(row.Efficiency, 'f', 3, 64),
+		}
+		for _, t := range row.Times {
+			record = append(record, seconds(t))
+		}
+		out.Write(record)
+	}
+	out.Flush()
+	return out.Error()
+}
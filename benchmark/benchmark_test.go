@@ -0,0 +1,95 @@
+package benchmark
+
+// Unit testing for benchmark.go and chart.go. Tests the following functions:
+// median
+// stddev
+// Run
+// WriteCSV
+// Chart
+//
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test the median and standard deviation of odd and even counts
+func TestMedianStddev(t *testing.T) {
+	times := []time.Duration{3, 1, 2}
+	if median(times) != 2 || times[0] != 3 {
+		t.Errorf("Expected a median of 2 without sorting the input, got %v", median(times))
+	}
+	if got := median([]time.Duration{4, 1, 3, 2}); got != 2 {
+		t.Errorf("Expected a median of 2, got %v", got)
+	}
+	if got := stddev([]time.Duration{2, 4, 4, 4, 5, 5, 7, 9}); got != 2 {
+		t.Errorf("Expected a standard deviation of 2, got %v", got)
+	}
+	if got := stddev([]time.Duration{5}); got != 0 {
+		t.Errorf("Expected no deviation for a single time, got %v", got)
+	}
+}
+
+// Test a small matrix measures the baseline and each strategy and thread count
+func TestRun(t *testing.T) {
+	var progress int
+	m := Matrix{
+		Cases:      []Case{{Config: "../data/extraeasyMaze.txt", Samples: 50}},
+		Strategies: []string{"ws", "bsp"},
+		Threads:    []int{2, 3},
+		Warmup:     1,
+		Reps:       2,
+		Seed:       1,
+		Progress:   func(Row) { progress++ },
+	}
+	rows, err := Run(context.Background(), m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 5 || progress != 5 {
+		t.Fatalf("Expected 5 rows and progress reports, got %d and %d", len(rows), progress)
+	}
+	if rows[0].Strategy != "sequential" || rows[0].Speedup != 1 {
+		t.Errorf("Expected the sequential baseline first, got %+v", rows[0])
+	}
+	for _, row := range rows[1:] {
+		if len(row.Times) != 2 || row.Speedup <= 0 ||
+			row.Efficiency != row.Speedup/float64(row.Threads) {
+			t.Errorf("Expected 2 timed runs and a speedup, got %+v", row)
+		}
+	}
+
+	var csv strings.Builder
+	if err := WriteCSV(&csv, rows); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if len(lines) != 6 || !strings.HasSuffix(lines[0], "rep1_s,rep2_s") {
+		t.Errorf("Expected a header with 2 reps and 5 rows, got %q", lines)
+	}
+
+	// A panel for each parallel strategy
+	if bounds := Chart(rows).Bounds(); bounds.Dx() != 2*panelWidth || bounds.Dy() != panelHeight {
+		t.Errorf("Expected two panels, got %v", bounds)
+	}
+}
+
+// Test bad matrices are rejected before running
+func TestRunInvalid(t *testing.T) {
+	c := []Case{{Config: "../data/extraeasyMaze.txt", Samples: 10}}
+	invalid := []Matrix{
+		{Reps: 1},
+		{Cases: []Case{{Config: "missing.txt", Samples: 10}}, Reps: 1},
+		{Cases: c, Strategies: []string{"dfs"}, Threads: []int{2}, Reps: 1},
+		{Cases: c, Strategies: []string{"ws"}, Threads: []int{1}, Reps: 1},
+		{Cases: c, Strategies: []string{"ws"}, Reps: 1},
+		{Cases: c},
+	}
+	for _, m := range invalid {
+		if _, err := Run(context.Background(), m); err == nil {
+			t.Errorf("Expected an error for %+v", m)
+		}
+	}
+}
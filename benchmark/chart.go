@@ -0,0 +1,198 @@
+package benchmark
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/fogleman/gg"
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// Size of each strategy's panel in the chart
+const (
+	panelWidth  = 640
+	panelHeight = 480
+	plotMargin  = 60
+)
+
+// Colors of the cases' lines, repeated when there are more cases
+var lineColors = []color.Color{
+	color.RGBA{R: 31, G: 119, B: 180, A: 255},
+	color.RGBA{R: 255, G: 127, B: 14, A: 255},
+	color.RGBA{R: 44, G: 160, B: 44, A: 255},
+	color.RGBA{R: 214, G: 39, B: 40, A: 255},
+	color.RGBA{R: 148, G: 103, B: 189, A: 255},
+	color.RGBA{R: 140, G: 86, B: 75, A: 255},
+}
+
+// Chart draws the speedup against threads with a panel for each parallel
+// strategy, side by side. Each case is a line starting from the sequential
+// baseline, with the ideal linear speedup dashed
+func Chart(rows []Row) image.Image {
+	var strategies []string
+	for _, row := range rows {
+		if row.Strategy != "sequential" && !contains(strategies, row.Strategy) {
+			strategies = append(strategies, row.Strategy)
+		}
+	}
+	if len(strategies) == 0 {
+		strategies = []string{"sequential"}
+	}
+
+	screen := gg.NewContext(panelWidth*len(strategies), panelHeight)
+	screen.SetColor(color.White)
+	screen.Clear()
+	font, _ := truetype.Parse(goregular.TTF)
+	screen.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 13}))
+
+	for i, strategy := range strategies {
+		screen.Push()
+		screen.Translate(float64(i*panelWidth), 0)
+		drawPanel(screen, rows, strategy)
+		screen.Pop()
+	}
+	return screen.Image()
+}
+
+// WriteChart writes the speedup chart as a PNG
+func WriteChart(w io.Writer, rows []Row) error {
+	return png.Encode(w, Chart(rows))
+}
+
+// Draw the speedup of one strategy for every case
+func drawPanel(screen *gg.Context, rows []Row, strategy string) {
+	// Scale the axes to the largest thread count and speedup
+	maxThreads, maxSpeedup := 1.0, 1.0
+	for _, row := range rows {
+		if row.Strategy == strategy {
+			maxThreads = math.Max(maxThreads, float64(row.Threads))
+			maxSpeedup = math.Max(maxSpeedup, row.Speedup)
+		}
+	}
+	maxSpeedup = math.Max(2, math.Ceil(maxSpeedup*1.2))
+	left, right := float64(plotMargin), float64(panelWidth-plotMargin/2)
+	top, bottom := float64(plotMargin), float64(panelHeight-plotMargin)
+	toX := func(threads float64) float64 { return left + (right-left)*threads/maxThreads }
+	toY := func(speedup float64) float64 { return bottom - (bottom-top)*speedup/maxSpeedup }
+
+	// Grid and axis labels
+	screen.SetLineWidth(1)
+	for _, tick := range ticks(maxThreads) {
+		screen.SetColor(color.Gray{Y: 225})
+		screen.DrawLine(toX(tick), top, toX(tick), bottom)
+		screen.Stroke()
+		screen.SetColor(color.Black)
+		screen.DrawStringAnchored(fmt.Sprint(tick), toX(tick), bottom+8, 0.5, 1)
+	}
+	for _, tick := range ticks(maxSpeedup) {
+		screen.SetColor(color.Gray{Y: 225})
+		screen.DrawLine(left, toY(tick), right, toY(tick))
+		screen.Stroke()
+		screen.SetColor(color.Black)
+		screen.DrawStringAnchored(fmt.Sprint(tick), left-8, toY(tick), 1, 0.35)
+	}
+	screen.SetColor(color.Black)
+	screen.DrawRectangle(left, top, right-left, bottom-top)
+	screen.Stroke()
+	screen.DrawStringAnchored(fmt.Sprintf("Robot Pathfinder Speedup (%s)", strategy),
+		(left+right)/2, top/2, 0.5, 0.5)
+	screen.DrawStringAnchored("Threads", (left+right)/2, bottom+plotMargin/2+8, 0.5, 0.5)
+	screen.Push()
+	screen.RotateAbout(-math.Pi/2, left/3, (top+bottom)/2)
+	screen.DrawStringAnchored("Speedup", left/3, (top+bottom)/2, 0.5, 0.5)
+	screen.Pop()
+
+	// Ideal linear speedup
+	screen.SetColor(color.Gray{Y: 150})
+	screen.SetDash(6, 4)
+	ideal := math.Min(maxThreads, maxSpeedup)
+	screen.DrawLine(toX(0), toY(0), toX(ideal), toY(ideal))
+	screen.Stroke()
+	screen.SetDash()
+
+	// A line for each case, from its baseline through each thread count
+	var labels []string
+	for _, c := range cases(rows) {
+		col := lineColors[len(labels)%len(lineColors)]
+		screen.SetColor(col)
+		screen.SetLineWidth(2)
+		var points [][2]float64
+		for _, row := range rows {
+			if row.Config == c.Config && row.Samples == c.Samples &&
+				(row.Strategy == strategy || row.Strategy == "sequential") {
+				points = append(points, [2]float64{toX(float64(row.Threads)), toY(row.Speedup)})
+			}
+		}
+		for i, pt := range points {
+			if i == 0 {
+				screen.MoveTo(pt[0], pt[1])
+			} else {
+				screen.LineTo(pt[0], pt[1])
+			}
+		}
+		screen.Stroke()
+		for _, pt := range points {
+			screen.DrawCircle(pt[0], pt[1], 3)
+			screen.Fill()
+		}
+		labels = append(labels, fmt.Sprintf("%s (%d samples)", caseName(c.Config), c.Samples))
+	}
+
+	// Legend in the top left of the plot
+	for i, label := range labels {
+		y := top + 16 + float64(i)*18
+		screen.SetColor(lineColors[i%len(lineColors)])
+		screen.DrawRectangle(left+10, y-5, 14, 4)
+		screen.Fill()
+		screen.SetColor(color.Black)
+		screen.DrawStringAnchored(label, left+30, y, 0, 0.35)
+	}
+}
+
+// Get the distinct cases of the rows in order
+func cases(rows []Row) []Case {
+	var found []Case
+	for _, row := range rows {
+		c := Case{row.Config, row.Samples}
+		seen := false
+		for _, other := range found {
+			seen = seen || other == c
+		}
+		if !seen {
+			found = append(found, c)
+		}
+	}
+	return found
+}
+
+// Name a case by its configuration file without directory or extension
+func caseName(config string) string {
+	return strings.TrimSuffix(filepath.Base(config), filepath.Ext(config))
+}
+
+// Get whole-number ticks from zero to a maximum, at most about ten of them
+func ticks(max float64) []float64 {
+	step := math.Max(1, math.Ceil(max/10))
+	var values []float64
+	for v := 0.0; v <= max; v += step {
+		values = append(values, v)
+	}
+	return values
+}
+
+// Check if a list of strings holds a value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -1,129 +1,217 @@
 package concurrent
 
 import (
-	"math"
-	"proj3-redesigned/robotpath"
-	"proj3-redesigned/rrtstar"
+	"context"
 	"sync"
-	"sync/atomic"
 )
 
+// SuperstepHook is called at each barrier with the tasks completed in the
+// superstep that just ended, while every worker is waiting. It is where
+// results are exchanged between supersteps, it must not call Submit
+type SuperstepHook[T any] func(superstep int, completed []*T)
+
 // BSP executor service
-type BSPExecutor struct {
-	ctx *bspContext 	// BSP context
-	wg  sync.WaitGroup  // WaitGroup for tracking task completion
+type BSPExecutor[T any, U any] struct {
+	ctx  *bspContext[T]      // BSP context
+	wg   sync.WaitGroup      // WaitGroup for tracking task completion
+	call func(*T) (U, error) // Runs a task for its result
+	stop sync.Once           // Shuts the executor down once
 }
 
 // BSP context
-type bspContext struct {
-	numWorkers   int32 					// Number of workers
-	numSyncing   atomic.Int32 			// Number of workers syncing
-	taskBuffer   []*rrtstar.PathUpdate 	// All remaining tasks
-	curWork      []*rrtstar.PathUpdate 	// Worker's current task
-	syncMessages []*robotpath.MileStone // Messages used for synchronization
-	cond         sync.Cond 				// Condition variable for synchronization
-	shutdown     chan interface{} 		// Channel for shutdown
+type bspContext[T any] struct {
+	numWorkers int              // Number of workers
+	arrived    int              // Number of workers waiting at the barrier
+	generation int              // Number of barriers passed
+	batch      int              // Tasks per worker per superstep
+	taskBuffer []*job[T]        // All remaining tasks, in submission order
+	curWork    [][]*job[T]      // Worker's current tasks
+	cond       sync.Cond        // Condition variable for synchronization
+	shutdown   chan interface{} // Channel for shutdown
+	superstep  int              // Number of completed supersteps
+	hook       SuperstepHook[T] // Called between supersteps, may be nil
+	ctx        context.Context  // Context of the execution
 }
 
 // NewBSPExecutor returns an ExecutorService that is implemented using the BSP
-// scheduling strategy
-func NewBSPExecutor(threads int) ExecutorService[rrtstar.PathUpdate, any] {
+// scheduling strategy. Each superstep runs a batch of tasks per worker, one by
+// default, and hook is called at the barrier with the completed tasks in
+// submission order
+func NewBSPExecutor[T any, P RunnablePtr[T]](threads int, hook SuperstepHook[T],
+) *BSPExecutor[T, any] {
+	return newBSPExecutor(threads, hook, runTask[T, P])
+}
+
+// NewCallableBSPExecutor returns a BSP ExecutorService for Callable tasks. A
+// future gets the result of its task once the task's superstep has ended
+func NewCallableBSPExecutor[T any, U any, P CallablePtr[T, U]](threads int, hook SuperstepHook[T],
+) *BSPExecutor[T, U] {
+	return newBSPExecutor(threads, hook, callTask[T, U, P])
+}
+
+// Create a BSP executor that runs its tasks with call
+func newBSPExecutor[T any, U any](threads int, hook SuperstepHook[T], call func(*T) (U, error),
+) *BSPExecutor[T, U] {
 	// Create BSP context
-	context := bspContext{
-		numWorkers:   int32(threads),
-		numSyncing:   atomic.Int32{},
-		taskBuffer:   make([]*rrtstar.PathUpdate, 0),
-		curWork:      make([]*rrtstar.PathUpdate, threads),
-		syncMessages: make([]*robotpath.MileStone, threads),
-		cond:         *sync.NewCond(&sync.Mutex{}),
-		shutdown:     make(chan interface{}),
+	context := bspContext[T]{
+		numWorkers: threads,
+		batch:      1,
+		taskBuffer: make([]*job[T], 0),
+		curWork:    make([][]*job[T], threads),
+		cond:       *sync.NewCond(&sync.Mutex{}),
+		shutdown:   make(chan interface{}),
+		hook:       hook,
 	}
 	// Create executor
-	executor := &BSPExecutor{
-		ctx: &context,
-		wg:  sync.WaitGroup{},
+	executor := &BSPExecutor[T, U]{
+		ctx:  &context,
+		wg:   sync.WaitGroup{},
+		call: call,
 	}
 	return executor
 }
 
-// Submits a task to the executor
-func (e *BSPExecutor) Submit(task *rrtstar.PathUpdate) Future[any] {
-	e.ctx.taskBuffer = append(e.ctx.taskBuffer, task)
-	return &RunnableFuture{Done: task.Done}
+// SetBatch sets the number of tasks each worker runs per superstep, at least
+// one. It must be called before Execute
+func (e *BSPExecutor[T, U]) SetBatch(batch int) {
+	if batch < 1 {
+		batch = 1
+	}
+	e.ctx.batch = batch
 }
 
-// Executes the executor
-func (e *BSPExecutor) Execute() {
+// Submits a task to the executor. Submit is safe from any goroutine, a task
+// submitted while running joins a later superstep. Tasks submitted once the
+// workers ran out of tasks or after Shutdown are cancelled with ErrShutdown
+func (e *BSPExecutor[T, U]) Submit(task *T) Future[U] {
+	j, future := newJob(task, e.call)
+	// The barrier assigns the buffered tasks under the same lock
+	e.ctx.cond.L.Lock()
+	defer e.ctx.cond.L.Unlock()
+	select {
+	case <-e.ctx.shutdown:
+		j.cancel(ErrShutdown)
+		j.complete()
+		return future
+	default:
+	}
+	e.ctx.taskBuffer = append(e.ctx.taskBuffer, j)
+	return future
+}
+
+// Executes the executor, the workers stop at the barrier after the context is
+// done
+func (e *BSPExecutor[T, U]) Execute(ctx context.Context) {
+	e.ctx.ctx = ctx
 	// Define worker loop
-	runBSPWorker := func(id int, ctx *bspContext) {
+	runBSPWorker := func(id int, ctx *bspContext[T]) {
 		defer e.wg.Done()
 		for {
+			// Sync with other threads
+			ctx.Sync()
 			select {
-			case <-e.ctx.shutdown:
+			case <-ctx.shutdown:
 				return
 			default:
-				// Sync with other threads
-				ctx.Sync(id)
 				// Execute work
-				if ctx.curWork[id] != nil {
-					ctx.curWork[id].Run()
-					ctx.syncMessages[id] = ctx.curWork[id].GetMileStone()
+				for _, j := range ctx.curWork[id] {
+					j.run()
 				}
 			}
 		}
 	}
 	// Run the workers
-	for worker := 0; worker < int(e.ctx.numWorkers); worker++ {
+	for worker := 0; worker < e.ctx.numWorkers; worker++ {
 		e.wg.Add(1)
 		go runBSPWorker(worker, e.ctx)
 	}
 }
 
-// Shuts down the executor
-func (e *BSPExecutor) Shutdown() {
+// Shuts down the executor, without Execute the submitted tasks are cancelled
+// with ErrShutdown. Later calls only wait for the workers
+func (e *BSPExecutor[T, U]) Shutdown() {
+	e.stop.Do(func() {
+		e.ctx.cond.L.Lock()
+		defer e.ctx.cond.L.Unlock()
+		if e.ctx.ctx == nil {
+			for _, j := range e.ctx.taskBuffer {
+				j.cancel(ErrShutdown)
+				j.complete()
+			}
+			e.ctx.taskBuffer = nil
+			close(e.ctx.shutdown)
+		}
+	})
 	e.wg.Wait()
 }
 
-// Synchronizes the workers in between the BSP steps
-func (ctx *bspContext) Sync(id int) {
-	// Check if thread last to finish task, if so run update() to synchronize
+// Synchronizes the workers in between the BSP steps. The last worker to arrive
+// runs update(), the others wait until the barrier's generation has passed
+func (ctx *bspContext[T]) Sync() {
 	ctx.cond.L.Lock()
-	if ctx.numSyncing.Load() < ctx.numWorkers-1 {
-		ctx.numSyncing.Add(1)
-		ctx.cond.Wait()
-		ctx.numSyncing.Add(-1)
-	} else {
-		ctx.update()
-		ctx.cond.Broadcast()
+	defer ctx.cond.L.Unlock()
+	ctx.arrived++
+	if ctx.arrived < ctx.numWorkers {
+		generation := ctx.generation
+		for generation == ctx.generation {
+			ctx.cond.Wait()
+		}
+		return
 	}
-	ctx.cond.L.Unlock()
+	ctx.arrived = 0
+	ctx.update()
+	ctx.generation++
+	ctx.cond.Broadcast()
 }
 
-// Updates the BSP context in between steps
-func (ctx *bspContext) update() {
-	// Update the cost of the new milestones
-	for _, newMilestone := range ctx.syncMessages {
-		if newMilestone != nil {
-			newMilestone.UpdateChildrenCost()
+// Updates the BSP context in between steps. Worker w is given the next tasks
+// in submission order from w*batch, so the tasks completed in a superstep are
+// passed to the hook in the order they were submitted, whatever order they ran in
+func (ctx *bspContext[T]) update() {
+	// Pass the tasks of the finished superstep to the hook
+	var completed []*T
+	for _, work := range ctx.curWork {
+		for _, j := range work {
+			completed = append(completed, j.task)
 		}
 	}
-
-	// Update current work for each worker	
-	numTasks := len(ctx.taskBuffer)
-	for i := 0; i < int(ctx.numWorkers); i++ {
-		if numTasks > i {
-			ctx.curWork[i] = ctx.taskBuffer[numTasks-i-1]
-		} else {
-			ctx.curWork[i] = nil
+	if len(completed) > 0 {
+		if ctx.hook != nil {
+			ctx.hook(ctx.superstep, completed)
+		}
+		for _, work := range ctx.curWork {
+			for _, j := range work {
+				j.complete()
+			}
 		}
+		ctx.superstep++
 	}
 
-	// Update task buffer
-	newEnd := numTasks - int(math.Min(float64(numTasks), float64(ctx.numWorkers)))
+	// Cancel the remaining tasks once the context is done
+	if err := ctx.ctx.Err(); err != nil {
+		for _, j := range ctx.taskBuffer {
+			j.cancel(err)
+			j.complete()
+		}
+		ctx.taskBuffer = nil
+	}
 
-	ctx.taskBuffer = ctx.taskBuffer[:newEnd]
+	// Update current work for each worker
+	numTasks := len(ctx.taskBuffer)
+	next := 0
+	for i := 0; i < ctx.numWorkers; i++ {
+		end := next + ctx.batch
+		if end > numTasks {
+			end = numTasks
+		}
+		ctx.curWork[i] = ctx.taskBuffer[next:end]
+		next = end
+	}
 
-	if len(ctx.taskBuffer) == 0 {
+	// Update task buffer, shutting down once every task is synchronized
+	if numTasks == 0 {
 		close(ctx.shutdown)
+		return
 	}
+	ctx.taskBuffer = ctx.taskBuffer[next:]
 }
@@ -1,23 +1,46 @@
 package concurrent
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
 // Runnable represents a task that does not return a value.
 type Runnable interface {
 	Run() // Starts the execution of a Runnable
 }
 
-// Callable represents a task that will return a value.
+// Callable represents a task that will return a value or an error.
 type Callable[T any] interface {
-	Call() T // Starts the execution of a Callable
+	Call() (T, error) // Starts the execution of a Callable
+}
+
+// RunnablePtr is satisfied by pointers to task types that implement Runnable,
+// so an executor can be created from the task type alone
+type RunnablePtr[T any] interface {
+	*T
+	Runnable
+}
+
+// CallablePtr is satisfied by pointers to task types that implement
+// Callable[U]
+type CallablePtr[T any, U any] interface {
+	*T
+	Callable[U]
 }
 
 // Future represents the value that is returned after executing a Runnable or
 // Callable task.
 type Future[T any] interface {
 	// Get waits (if necessary) for the task to complete. If the task associated
-	// with the Future is a Callable Task then it will return the value returned
-	// by the Call method. If the task associated with the Future is a Runnable
-	// then it must return nil once the task is complete.
-	Get() T
+	// with the Future is a Callable Task then it will return the value and error
+	// returned by the Call method. If the task associated with the Future is a
+	// Runnable then it must return nil once the task is complete. A task that
+	// panics returns a *PanicError, and a task cancelled before it ran returns
+	// the error of the executor's context.
+	Get() (T, error)
 }
 
 // ExecutorService represents a service that can run om Runnable and/or Callable
@@ -27,24 +50,84 @@ type ExecutorService[T any, U any] interface {
 	// Submits a task for execution and returns a Future representing that task.
 	Submit(task *T) Future[U]
 
-	// Execute starts the execution
-	Execute()
+	// Execute starts the execution, which stops early once the context is done
+	Execute(ctx context.Context)
 
-	// Shutdown initiates a shutdown of the service. It is unsafe to call Shutdown
-	// at the same time as the Submit method. All tasks must be submitted before
-	// calling Shutdown. All Submit calls during and after the call to the Shutdown
-	// method will be ignored. A goroutine that calls Shutdown is blocked until
+	// Shutdown initiates a shutdown of the service. Executors that accept
+	// submissions while running let Submit race with Shutdown, each task is
+	// then either run or cancelled, otherwise all tasks must be submitted
+	// before Execute. Tasks submitted after Shutdown are cancelled
+	// with ErrShutdown. A goroutine that calls Shutdown is blocked until
 	// the service is completely shutdown (i.e., no more pending tasks and all
-	// goroutines spawned by the service are terminated).
+	// goroutines spawned by the service are terminated). Tasks that had not run
+	// when the context of Execute was done are cancelled, and without Execute
+	// every task is cancelled with ErrShutdown.
 	Shutdown()
 }
 
-// RunnableFuture implements the Future interface
-type RunnableFuture struct {
-	Done chan any
+// ErrShutdown is the error of a task submitted after the executor shut down
+var ErrShutdown = errors.New("executor is shut down")
+
+// PanicError is the error of a task that panicked, the executor keeps running
+// the other tasks
+type PanicError struct {
+	Value any    // Value passed to panic
+	Stack []byte // Stack of the panicking task
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("task panicked: %v", e.Value)
+}
+
+// TaskFuture implements the Future interface, done is closed once the task's
+// result is set
+type TaskFuture[T any] struct {
+	done  chan any
+	value T
+	err   error
+}
+
+func (f *TaskFuture[T]) Get() (T, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// A submitted task, run or cancel sets the result of its future and complete
+// makes the result available
+type job[T any] struct {
+	task     *T
+	run      func()
+	cancel   func(err error)
+	complete func()
+}
+
+// Wrap a task in a job whose future gets the result of call, recovering a
+// panic in the task as a PanicError
+func newJob[T any, U any](task *T, call func(*T) (U, error)) (*job[T], Future[U]) {
+	f := &TaskFuture[U]{done: make(chan any)}
+	run := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				f.err = &PanicError{Value: r, Stack: debug.Stack()}
+			}
+		}()
+		f.value, f.err = call(task)
+	}
+	return &job[T]{
+		task:     task,
+		run:      run,
+		cancel:   func(err error) { f.err = err },
+		complete: func() { close(f.done) },
+	}, f
+}
+
+// Run a Runnable task, its result is always nil
+func runTask[T any, P RunnablePtr[T]](task *T) (any, error) {
+	P(task).Run()
+	return nil, nil
 }
 
-func (f *RunnableFuture) Get() any {
-	<-f.Done
-	return nil
+// Call a Callable task for its result
+func callTask[T any, U any, P CallablePtr[T, U]](task *T) (U, error) {
+	return P(task).Call()
 }
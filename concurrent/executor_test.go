@@ -0,0 +1,477 @@
+package concurrent
+
+// Unit testing for executor.go, workstealing.go and bsp.go. Tests the following
+// functions:
+// NewWorkStealingExecutor
+// NewCallableWorkStealingExecutor
+// NewBSPExecutor
+// NewCallableBSPExecutor
+// SetBatch
+// Submit
+// Execute
+// Shutdown
+//
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// A task counting its runs, shared by every task of a test
+type countTask struct {
+	runs *atomic.Int64
+	ran  bool
+}
+
+func (task *countTask) Run() {
+	task.runs.Add(1)
+	task.ran = true
+}
+
+// A task squaring its input, failing on negative inputs and panicking on zero
+type squareTask struct {
+	n int
+}
+
+func (task *squareTask) Call() (int, error) {
+	if task.n < 0 {
+		return 0, errors.New("negative input")
+	}
+	if task.n == 0 {
+		panic("zero input")
+	}
+	return task.n * task.n, nil
+}
+
+// Submit tasks to an executor, run them to completion and wait on their futures
+func runCounted(t *testing.T, executor ExecutorService[countTask, any], n int) []*countTask {
+	var runs atomic.Int64
+	tasks := make([]*countTask, n)
+	futures := make([]Future[any], n)
+	for i := range tasks {
+		tasks[i] = &countTask{runs: &runs}
+		futures[i] = executor.Submit(tasks[i])
+	}
+	executor.Execute(context.Background())
+	executor.Shutdown()
+	for _, future := range futures {
+		if value, err := future.Get(); value != nil || err != nil {
+			t.Errorf("Expected nil from a runnable's future, got %v and %v", value, err)
+		}
+	}
+	if runs.Load() != int64(n) {
+		t.Errorf("Expected %d runs, got %d", n, runs.Load())
+	}
+	return tasks
+}
+
+// Test the work stealing executor runs every task once
+func TestWorkStealingExecutor(t *testing.T) {
+	for _, threads := range []int{1, 2, 4, 8} {
+		runCounted(t, NewWorkStealingExecutor[countTask](threads, 10), 1000)
+	}
+}
+
+// A task submitting two children until its depth runs out
+type spawnTask struct {
+	depth    int
+	runs     *atomic.Int64
+	executor ExecutorService[spawnTask, any]
+}
+
+func (task *spawnTask) Run() {
+	task.runs.Add(1)
+	if task.depth > 0 {
+		for i := 0; i < 2; i++ {
+			task.executor.Submit(&spawnTask{depth: task.depth - 1, runs: task.runs, executor: task.executor})
+		}
+	}
+}
+
+// Test tasks submitted while the pool runs, from other goroutines and from
+// running tasks, are all run before shutdown, and later ones are cancelled
+func TestWorkStealingSubmitRunning(t *testing.T) {
+	var runs atomic.Int64
+	executor := NewWorkStealingExecutor[spawnTask](4, 10)
+	executor.Submit(&spawnTask{depth: 8, runs: &runs, executor: executor})
+	executor.Execute(context.Background())
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 250; i++ {
+				executor.Submit(&spawnTask{runs: &runs, executor: executor})
+			}
+		}()
+	}
+	wg.Wait()
+	executor.Shutdown()
+
+	// A tree of depth 8 has 511 tasks
+	if runs.Load() != 511+1000 {
+		t.Errorf("Expected %d runs, got %d", 511+1000, runs.Load())
+	}
+	future := executor.Submit(&spawnTask{runs: &runs, executor: executor})
+	if _, err := future.Get(); err != ErrShutdown {
+		t.Errorf("Expected ErrShutdown after shutdown, got %v", err)
+	}
+}
+
+// A task blocking until released
+type blockTask struct {
+	release chan any
+}
+
+func (task *blockTask) Run() {
+	<-task.release
+}
+
+// Test idle workers park while a task runs, and wake to shut down
+func TestWorkStealingParking(t *testing.T) {
+	executor := newWorkStealingExecutor(4, 10, runTask[blockTask])
+	task := &blockTask{release: make(chan any)}
+	future := executor.Submit(task)
+	executor.Execute(context.Background())
+
+	deadline := time.Now().Add(5 * time.Second)
+	for executor.parked.Load() != 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if parked := executor.parked.Load(); parked != 3 {
+		t.Errorf("Expected the 3 idle workers to park, got %d", parked)
+	}
+	close(task.release)
+	executor.Shutdown()
+	if _, err := future.Get(); err != nil {
+		t.Errorf("Expected the task to run, got %v", err)
+	}
+	if parked := executor.parked.Load(); parked != 0 {
+		t.Errorf("Expected every worker to wake on shutdown, got %d parked", parked)
+	}
+}
+
+// Test the BSP executor runs every task once, passing each to the hook at the
+// barrier of its superstep
+func TestBSPExecutor(t *testing.T) {
+	for _, n := range []int{0, 1, 3, 4, 1001} {
+		var supersteps, hooked int
+		hook := func(superstep int, completed []*countTask) {
+			if superstep != supersteps || len(completed) == 0 || len(completed) > 4 {
+				t.Errorf("Expected superstep %d with 1 to 4 tasks, got %d with %d",
+					supersteps, superstep, len(completed))
+			}
+			for _, task := range completed {
+				if !task.ran {
+					t.Errorf("Expected completed tasks to have run")
+				}
+			}
+			supersteps++
+			hooked += len(completed)
+		}
+		runCounted(t, NewBSPExecutor[countTask](4, hook), n)
+		if hooked != n || supersteps != (n+3)/4 {
+			t.Errorf("Expected %d tasks in %d supersteps, got %d in %d", n, (n+3)/4, hooked, supersteps)
+		}
+	}
+	runCounted(t, NewBSPExecutor[countTask](3, nil), 100)
+}
+
+// Test each BSP worker runs a batch of tasks per superstep, with the completed
+// tasks passed to the hook in submission order
+func TestBSPBatch(t *testing.T) {
+	for _, n := range []int{0, 11, 12, 1001} {
+		var supersteps int
+		var order []int
+		var runs atomic.Int64
+		tasks := make(map[*countTask]int)
+		hook := func(superstep int, completed []*countTask) {
+			if len(completed) == 0 || len(completed) > 12 {
+				t.Errorf("Expected 1 to 12 tasks in superstep %d, got %d", superstep, len(completed))
+			}
+			for _, task := range completed {
+				order = append(order, tasks[task])
+			}
+			supersteps++
+		}
+		executor := NewBSPExecutor[countTask](4, hook)
+		executor.SetBatch(3)
+		futures := make([]Future[any], n)
+		for i := range futures {
+			task := &countTask{runs: &runs}
+			tasks[task] = i
+			futures[i] = executor.Submit(task)
+		}
+		executor.Execute(context.Background())
+		executor.Shutdown()
+
+		if supersteps != (n+11)/12 {
+			t.Errorf("Expected %d supersteps for %d tasks, got %d", (n+11)/12, n, supersteps)
+		}
+		for i := range order {
+			if order[i] != i {
+				t.Fatalf("Expected the tasks in submission order, got task %d at %d", order[i], i)
+			}
+		}
+		if len(order) != n || runs.Load() != int64(n) {
+			t.Errorf("Expected %d tasks run and completed, got %d and %d", n, runs.Load(), len(order))
+		}
+	}
+}
+
+// Test tasks submitted while the BSP workers run are run or cancelled
+func TestBSPSubmitRunning(t *testing.T) {
+	for round := 0; round < 50; round++ {
+		executor := NewBSPExecutor[countTask](4, nil)
+		var runs atomic.Int64
+		for i := 0; i < 100; i++ {
+			executor.Submit(&countTask{runs: &runs})
+		}
+		executor.Execute(context.Background())
+
+		var wg sync.WaitGroup
+		futures := make([][]Future[any], 4)
+		for i := range futures {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for k := 0; k < 50; k++ {
+					futures[i] = append(futures[i], executor.Submit(&countTask{runs: &runs}))
+				}
+			}(i)
+		}
+		wg.Wait()
+		executor.Shutdown()
+
+		var cancelled int64
+		for _, submitted := range futures {
+			for _, future := range submitted {
+				if _, err := future.Get(); errors.Is(err, ErrShutdown) {
+					cancelled++
+				} else if err != nil {
+					t.Fatalf("Expected the task to run or be cancelled, got %v", err)
+				}
+			}
+		}
+		if runs.Load()+cancelled != 300 {
+			t.Fatalf("Expected 300 tasks run or cancelled, got %d and %d", runs.Load(), cancelled)
+		}
+	}
+}
+
+// Test callable futures get their task's result or error, with panics
+// recovered and the other tasks still run
+func TestCallableExecutors(t *testing.T) {
+	executors := map[string]ExecutorService[squareTask, int]{
+		"ws":  NewCallableWorkStealingExecutor[squareTask, int](3, 10),
+		"bsp": NewCallableBSPExecutor[squareTask, int](3, nil),
+	}
+	for name, executor := range executors {
+		futures := make([]Future[int], 100)
+		for i := range futures {
+			futures[i] = executor.Submit(&squareTask{n: i - 1})
+		}
+		executor.Execute(context.Background())
+		executor.Shutdown()
+
+		if _, err := futures[0].Get(); err == nil || err.Error() != "negative input" {
+			t.Errorf("Expected %s to return the task's error, got %v", name, err)
+		}
+		var panicErr *PanicError
+		if _, err := futures[1].Get(); !errors.As(err, &panicErr) || panicErr.Value != "zero input" {
+			t.Errorf("Expected %s to recover the panic, got %v", name, err)
+		}
+		for i, future := range futures[2:] {
+			if value, err := future.Get(); err != nil || value != (i+1)*(i+1) {
+				t.Errorf("Expected %s to square %d, got %d and %v", name, i+1, value, err)
+			}
+		}
+	}
+}
+
+// A task cancelling the context of its executor when run
+type cancelTask struct {
+	cancel func()
+	ran    bool
+}
+
+func (task *cancelTask) Run() {
+	task.ran = true
+	if task.cancel != nil {
+		task.cancel()
+	}
+}
+
+// Test cancelling the context stops the executors, with the tasks that did
+// not run reported as cancelled
+func TestExecutorCancel(t *testing.T) {
+	executors := map[string]func() ExecutorService[cancelTask, any]{
+		"ws":  func() ExecutorService[cancelTask, any] { return NewWorkStealingExecutor[cancelTask](4, 10) },
+		"bsp": func() ExecutorService[cancelTask, any] { return NewBSPExecutor[cancelTask](4, nil) },
+	}
+	for name, newExecutor := range executors {
+		ctx, cancel := context.WithCancel(context.Background())
+		executor := newExecutor()
+		tasks := make([]*cancelTask, 10000)
+		futures := make([]Future[any], len(tasks))
+		for i := range tasks {
+			tasks[i] = &cancelTask{}
+			futures[i] = executor.Submit(tasks[i])
+		}
+		// BSP runs tasks from the front of the buffer, work-stealing workers
+		// pop theirs from the back of their queues
+		tasks[4].cancel = cancel
+		tasks[len(tasks)-5].cancel = cancel
+		executor.Execute(ctx)
+		executor.Shutdown()
+
+		var ran, cancelled int
+		for i, future := range futures {
+			_, err := future.Get()
+			switch {
+			case err == nil && tasks[i].ran:
+				ran++
+			case errors.Is(err, context.Canceled) && !tasks[i].ran:
+				cancelled++
+			default:
+				t.Errorf("Expected %s to run or cancel task %d, got %v", name, i, err)
+			}
+		}
+		if cancelled == 0 || ran+cancelled != len(tasks) {
+			t.Errorf("Expected %s to cancel the remaining tasks, %d ran and %d were cancelled",
+				name, ran, cancelled)
+		}
+	}
+}
+
+// Test shutting down without Execute cancels the submitted tasks, and later
+// submissions
+func TestShutdownWithoutExecute(t *testing.T) {
+	executors := map[string]ExecutorService[cancelTask, any]{
+		"ws":  NewWorkStealingExecutor[cancelTask](4, 10),
+		"bsp": NewBSPExecutor[cancelTask](4, nil),
+	}
+	for name, executor := range executors {
+		futures := make([]Future[any], 10)
+		for i := range futures {
+			futures[i] = executor.Submit(&cancelTask{})
+		}
+		executor.Shutdown()
+		futures = append(futures, executor.Submit(&cancelTask{}))
+		for _, future := range futures {
+			if _, err := future.Get(); !errors.Is(err, ErrShutdown) {
+				t.Errorf("Expected %s to cancel the task with ErrShutdown, got %v", name, err)
+			}
+		}
+	}
+}
+
+// Test Shutdown can be called again after the executor shut down
+func TestShutdownTwice(t *testing.T) {
+	for _, execute := range []bool{false, true} {
+		executors := map[string]ExecutorService[countTask, any]{
+			"ws":  NewWorkStealingExecutor[countTask](4, 10),
+			"bsp": NewBSPExecutor[countTask](4, nil),
+		}
+		for name, executor := range executors {
+			var runs atomic.Int64
+			for i := 0; i < 10; i++ {
+				executor.Submit(&countTask{runs: &runs})
+			}
+			if execute {
+				executor.Execute(context.Background())
+			}
+			executor.Shutdown()
+			executor.Shutdown()
+			if execute && runs.Load() != 10 {
+				t.Errorf("Expected %s to run 10 tasks, ran %d", name, runs.Load())
+			}
+		}
+	}
+}
+
+// Test tasks submitted while the pool starts all run
+func TestWorkStealingSubmitExecute(t *testing.T) {
+	for round := 0; round < 50; round++ {
+		executor := NewWorkStealingExecutor[countTask](4, 10)
+		var runs atomic.Int64
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				for k := 0; k < 50; k++ {
+					executor.Submit(&countTask{runs: &runs})
+				}
+			}()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			executor.Execute(context.Background())
+		}()
+		close(start)
+		wg.Wait()
+		executor.Shutdown()
+		if runs.Load() != 200 {
+			t.Fatalf("Expected 200 tasks to run, ran %d", runs.Load())
+		}
+	}
+}
+
+// Test tasks submitted while the pool shuts down are run or cancelled
+func TestWorkStealingSubmitShutdown(t *testing.T) {
+	for round := 0; round < 200; round++ {
+		executor := NewWorkStealingExecutor[countTask](4, 10)
+		var runs atomic.Int64
+		executor.Submit(&countTask{runs: &runs})
+		executor.Execute(context.Background())
+
+		var wg sync.WaitGroup
+		futures := make([][]Future[any], 4)
+		for i := range futures {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for k := 0; k < 50; k++ {
+					futures[i] = append(futures[i], executor.Submit(&countTask{runs: &runs}))
+				}
+			}(i)
+		}
+		executor.Shutdown()
+		wg.Wait()
+
+		var cancelled int64
+		for _, submitted := range futures {
+			for _, future := range submitted {
+				done := make(chan error, 1)
+				go func(future Future[any]) {
+					_, err := future.Get()
+					done <- err
+				}(future)
+				select {
+				case err := <-done:
+					if errors.Is(err, ErrShutdown) {
+						cancelled++
+					} else if err != nil {
+						t.Fatalf("Expected the task to run or be cancelled, got %v", err)
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatalf("Expected every future to complete in round %d", round)
+				}
+			}
+		}
+		if runs.Load()+cancelled != 201 {
+			t.Fatalf("Expected 201 tasks run or cancelled, got %d run and %d cancelled",
+				runs.Load(), cancelled)
+		}
+	}
+}
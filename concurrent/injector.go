@@ -0,0 +1,38 @@
+package concurrent
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// injector is a queue of tasks submitted while the workers run, safe for any
+// number of producers and consumers
+type injector[T any] struct {
+	mu   sync.Mutex   // Lock for the jobs
+	jobs []*job[T]    // Jobs in submission order
+	size atomic.Int64 // Number of jobs, checked without the lock
+}
+
+// Add a job to the back of the queue
+func (q *injector[T]) push(j *job[T]) {
+	q.mu.Lock()
+	q.jobs = append(q.jobs, j)
+	q.size.Add(1)
+	q.mu.Unlock()
+}
+
+// Take up to n jobs from the front of the queue
+func (q *injector[T]) take(n int) []*job[T] {
+	if q.size.Load() == 0 {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if n > len(q.jobs) {
+		n = len(q.jobs)
+	}
+	taken := append([]*job[T]{}, q.jobs[:n]...)
+	q.jobs = q.jobs[n:]
+	q.size.Add(int64(-n))
+	return taken
+}
@@ -0,0 +1,67 @@
+package concurrent
+
+import (
+	"math/rand"
+	"time"
+)
+
+// StealPolicy selects the worker an idle worker steals from
+type StealPolicy int
+
+const (
+	RandomVictim     StealPolicy = iota // A uniformly random other worker
+	RoundRobinVictim                    // Each other worker in turn
+	MostLoadedVictim                    // The worker with the most queued tasks
+	LocalVictim                         // Neighbouring workers by id first, moving outwards while steals fail
+)
+
+// WorkerStats counts the work of one worker in a work-stealing pool
+type WorkerStats struct {
+	Tasks         int64         // Tasks run
+	StealAttempts int64         // Attempts to steal from another worker
+	Steals        int64         // Attempts that took at least one task
+	Stolen        int64         // Tasks taken by steals
+	Idle          time.Duration // Time spent without a task, backing off or parked
+}
+
+// Choose the worker to steal from with the executor's policy, there must be
+// another worker
+func (e *WorkStealingExecutor[T, U]) victim(me int) int {
+	n := len(e.workers)
+	w := e.workers[me]
+	switch e.policy {
+	case RoundRobinVictim:
+		w.cursor = (w.cursor + 1) % (n - 1)
+		return otherWorker(me, w.cursor)
+
+	case MostLoadedVictim:
+		victim, most := otherWorker(me, 0), -1
+		for i, other := range e.workers {
+			if size := other.queue.Size(); i != me && size > most {
+				victim, most = i, size
+			}
+		}
+		return victim
+
+	case LocalVictim:
+		// Alternate sides at growing distances: +1, -1, +2, -2, ...
+		k := w.cursor % (n - 1)
+		w.cursor++
+		offset := k/2 + 1
+		if k%2 == 0 {
+			return (me + offset) % n
+		}
+		return (me - offset + n) % n
+
+	default:
+		return otherWorker(me, rand.Intn(n-1))
+	}
+}
+
+// Get the k-th worker other than me
+func otherWorker(me int, k int) int {
+	if k >= me {
+		return k + 1
+	}
+	return k
+}
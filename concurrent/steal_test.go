@@ -0,0 +1,104 @@
+package concurrent
+
+// Unit testing for steal.go. Tests the following functions:
+// victim
+// SetPolicy
+// Stats
+//
+
+import (
+	"testing"
+)
+
+// Test each policy's order of victims
+func TestVictim(t *testing.T) {
+	executor := newWorkStealingExecutor(5, 10, runTask[countTask])
+
+	executor.SetPolicy(RoundRobinVictim)
+	var order []int
+	for i := 0; i < 5; i++ {
+		order = append(order, executor.victim(2))
+	}
+	if !equalInts(order, []int{1, 3, 4, 0, 1}) {
+		t.Errorf("Expected round-robin victims 1 3 4 0 1, got %v", order)
+	}
+
+	executor.SetPolicy(LocalVictim)
+	order = nil
+	for i := 0; i < 5; i++ {
+		order = append(order, executor.victim(0))
+	}
+	if !equalInts(order, []int{1, 4, 2, 3, 1}) {
+		t.Errorf("Expected local victims 1 4 2 3 1, got %v", order)
+	}
+
+	executor.SetPolicy(MostLoadedVictim)
+	for i := 0; i < 3; i++ {
+		executor.workers[3].queue.PushBottom(&job[countTask]{})
+	}
+	executor.workers[1].queue.PushBottom(&job[countTask]{})
+	if victim := executor.victim(0); victim != 3 {
+		t.Errorf("Expected the most loaded worker 3, got %d", victim)
+	}
+	if victim := executor.victim(3); victim != 1 {
+		t.Errorf("Expected the most loaded other worker 1, got %d", victim)
+	}
+
+	executor.SetPolicy(RandomVictim)
+	for i := 0; i < 100; i++ {
+		if victim := executor.victim(4); victim == 4 || victim < 0 || victim > 4 {
+			t.Fatalf("Expected another worker, got %d", victim)
+		}
+	}
+}
+
+// Test every policy runs all tasks with consistent statistics
+func TestStealPolicies(t *testing.T) {
+	for _, policy := range []StealPolicy{RandomVictim, RoundRobinVictim, MostLoadedVictim, LocalVictim} {
+		executor := NewWorkStealingExecutor[countTask](4, 10)
+		executor.SetPolicy(policy)
+		runCounted(t, executor, 1000)
+
+		var tasks int64
+		for i, stats := range executor.Stats() {
+			tasks += stats.Tasks
+			if stats.Steals > stats.StealAttempts || stats.Stolen < stats.Steals ||
+				stats.Stolen > 10*stats.Steals || stats.Idle < 0 {
+				t.Errorf("Expected consistent statistics for worker %d with policy %d, got %+v",
+					i, policy, stats)
+			}
+		}
+		if tasks != 1000 {
+			t.Errorf("Expected 1000 tasks counted with policy %d, got %d", policy, tasks)
+		}
+	}
+}
+
+// Test an idle worker steals half of a loaded worker's queue
+func TestStealHalf(t *testing.T) {
+	executor := newWorkStealingExecutor(2, 100, runTask[countTask])
+	for i := 0; i < 10; i++ {
+		executor.workers[0].queue.PushBottom(&job[countTask]{})
+	}
+	if j := executor.steal(1); j == nil || executor.workers[1].queue.Size() != 4 ||
+		executor.workers[0].queue.Size() != 5 {
+		t.Errorf("Expected 5 tasks stolen, got %d left", executor.workers[0].queue.Size())
+	}
+	stats := executor.Stats()[1]
+	if stats.StealAttempts != 1 || stats.Steals != 1 || stats.Stolen != 5 {
+		t.Errorf("Expected one steal of 5 tasks, got %+v", stats)
+	}
+}
+
+// Check if two lists of ints are equal
+func equalInts(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
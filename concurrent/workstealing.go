@@ -1,99 +1,355 @@
 package concurrent
 
 import (
-	"math/rand"
+	"context"
 	"proj3-redesigned/deque"
-	"proj3-redesigned/rrtstar"
+	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// Failed attempts to find work before an idle worker parks
+const idleSpins = 64
+
 // Work stealing executor service
-type WorkStealingExecutor struct {
-	workers   []*Worker        // The workers in the pool
-	wg        sync.WaitGroup   // WaitGroup for workers
-	threshold int              // Threshold for grabbing from the queue
-	shutdown  chan interface{} // Channel for shutdown
-	tasks     int              // Counter for initializing the queues
+type WorkStealingExecutor[T any, U any] struct {
+	workers   []*Worker[T]        // The workers in the pool
+	wg        sync.WaitGroup      // WaitGroup for workers
+	threshold int                 // Threshold for grabbing from the queue
+	shutdown  chan interface{}    // Channel for shutdown
+	closing   sync.RWMutex        // Held by Submit while queueing, taken to seed, start and close
+	stop      sync.Once           // Shuts the executor down once
+	tasks     int                 // Counter for initializing the queues
+	pending   atomic.Int64        // Number of tasks that have not run
+	idle      chan interface{}    // Signalled when the pending tasks reach zero
+	injected  injector[T]         // Tasks submitted while the workers run
+	running   atomic.Bool         // Set once the workers start
+	ctx       context.Context     // Context of the execution
+	epoch     atomic.Int64        // Moved on each time work may be available
+	parked    atomic.Int32        // Number of parked workers
+	mu        sync.Mutex          // Lock for parking
+	cond      *sync.Cond          // Condition variable parked workers wait on
+	policy    StealPolicy         // Selects the workers to steal from
+	call      func(*T) (U, error) // Runs a task for its result
 }
 
 // Worker struct
-type Worker struct {
-	queue deque.DEQue[rrtstar.PathUpdate]
+type Worker[T any] struct {
+	queue    deque.DEQue[job[T]]
+	cursor   int          // Position in the victim order of the steal policy
+	tasks    atomic.Int64 // Tasks run
+	attempts atomic.Int64 // Steal attempts
+	steals   atomic.Int64 // Steal attempts that took tasks
+	stolen   atomic.Int64 // Tasks taken by steals
+	idle     atomic.Int64 // Nanoseconds spent without a task
 }
 
 // NewWorkStealingExecutor returns an ExecutorService that is implemented using the
 // work-stealing algorithm. Capacity is the number of goroutines in the pool and
 // threshold is the number of items that a goroutine in the pool can grab from the
-// executor in one time period
-func NewWorkStealingExecutor(capacity, threshold int,
-) ExecutorService[rrtstar.PathUpdate, any] {
+// executor in one time period. A steal takes half of the victim's queue, up to
+// the threshold
+func NewWorkStealingExecutor[T any, P RunnablePtr[T]](capacity, threshold int,
+) *WorkStealingExecutor[T, any] {
+	return newWorkStealingExecutor(capacity, threshold, runTask[T, P])
+}
+
+// NewCallableWorkStealingExecutor returns a work-stealing ExecutorService for
+// Callable tasks, each future gets the result of its task
+func NewCallableWorkStealingExecutor[T any, U any, P CallablePtr[T, U]](capacity, threshold int,
+) *WorkStealingExecutor[T, U] {
+	return newWorkStealingExecutor(capacity, threshold, callTask[T, U, P])
+}
+
+// Create a work-stealing executor that runs its tasks with call
+func newWorkStealingExecutor[T any, U any](capacity, threshold int, call func(*T) (U, error),
+) *WorkStealingExecutor[T, U] {
 	// Create worker array
-	var workers []*Worker
+	var workers []*Worker[T]
 	for i := 0; i < capacity; i++ {
-		var worker Worker
-		worker.queue = deque.NewUnboundedDEQue[rrtstar.PathUpdate]()
+		var worker Worker[T]
+		worker.queue = deque.NewUnboundedDEQue[job[T]]()
 		workers = append(workers, &worker)
 	}
 	// Create executor
-	executor := &WorkStealingExecutor{
+	executor := &WorkStealingExecutor[T, U]{
 		workers:   workers,
 		wg:        sync.WaitGroup{},
 		threshold: threshold,
 		shutdown:  make(chan interface{}),
+		idle:      make(chan interface{}, 1),
+		ctx:       context.Background(),
+		call:      call,
 	}
+	executor.cond = sync.NewCond(&executor.mu)
 
 	return executor
 }
 
-// Submits a task to the executor
-func (e *WorkStealingExecutor) Submit(task *rrtstar.PathUpdate) Future[any] {
-	e.workers[e.tasks%len(e.workers)].queue.PushBottom(task)
-	e.wg.Add(1)
+// SetPolicy selects how idle workers choose whom to steal from, random by
+// default. It must be called before Execute
+func (e *WorkStealingExecutor[T, U]) SetPolicy(policy StealPolicy) {
+	e.policy = policy
+}
+
+// Stats returns the counts of each worker so far
+func (e *WorkStealingExecutor[T, U]) Stats() []WorkerStats {
+	stats := make([]WorkerStats, len(e.workers))
+	for i, w := range e.workers {
+		stats[i] = WorkerStats{
+			Tasks:         w.tasks.Load(),
+			StealAttempts: w.attempts.Load(),
+			Steals:        w.steals.Load(),
+			Stolen:        w.stolen.Load(),
+			Idle:          time.Duration(w.idle.Load()),
+		}
+	}
+	return stats
+}
+
+// Submits a task to the executor. Tasks submitted before Execute are spread
+// over the workers' queues, after which the workers take the task from a
+// shared queue. Submit is safe from any goroutine, including running tasks.
+// Tasks submitted after Shutdown are cancelled with ErrShutdown
+func (e *WorkStealingExecutor[T, U]) Submit(task *T) Future[U] {
+	j, future := newJob(task, e.call)
+	if !e.running.Load() && e.seed(j) {
+		return future
+	}
+	// Shutdown closes the executor under the write lock, so a task is either
+	// queued before Shutdown drains the queues or cancelled here
+	e.closing.RLock()
+	defer e.closing.RUnlock()
+	select {
+	case <-e.shutdown:
+		j.cancel(ErrShutdown)
+		j.complete()
+		return future
+	default:
+	}
+	e.pending.Add(1)
+	e.injected.push(j)
+	e.wake()
+	return future
+}
+
+// Queue a task submitted before Execute on the next worker, returns false if
+// the workers have started. The write lock keeps concurrent submissions off
+// the owner end of the queues until Execute takes it to start the workers
+func (e *WorkStealingExecutor[T, U]) seed(j *job[T]) bool {
+	e.closing.Lock()
+	defer e.closing.Unlock()
+	if e.running.Load() {
+		return false
+	}
+	select {
+	case <-e.shutdown:
+		j.cancel(ErrShutdown)
+		j.complete()
+		return true
+	default:
+	}
+	e.pending.Add(1)
+	e.workers[e.tasks%len(e.workers)].queue.PushBottom(j)
 	e.tasks++
-	return &RunnableFuture{Done: task.Done}
+	return true
 }
 
-func (e *WorkStealingExecutor) Execute() {
+// Executes the executor, workers stop taking tasks once the context is done
+func (e *WorkStealingExecutor[T, U]) Execute(ctx context.Context) {
+	e.closing.Lock()
+	e.ctx = ctx
+	e.running.Store(true)
+	e.closing.Unlock()
 	// Run the workers
 	for worker := 0; worker < len(e.workers); worker++ {
+		e.wg.Add(1)
 		go e.runWorker(worker)
 	}
-}
-
-// runWorkStealer is the main worker instructions for the worker stealing routine
-func (e *WorkStealingExecutor) runWorker(me int) {
-	for {
+	// Wake parked workers when the context is done
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
 		select {
+		case <-ctx.Done():
+			e.wake()
 		case <-e.shutdown:
-			return
-		default:
-			// If local queue is empty, steal from a random worker
-			if e.workers[me].queue.IsEmpty() {
-				randSteal := rand.Intn(len(e.workers))
-				for randSteal == me {
-					randSteal = rand.Intn(len(e.workers))
-				}
-				for i := 0; i < e.threshold; i++ {
-					task := e.workers[randSteal].queue.PopTop()
-					if task != nil {
-						e.workers[me].queue.PushBottom(task)
-					} else {
-						break
-					}
+		}
+	}()
+}
+
+// runWorkStealer is the main worker instructions for the worker stealing routine.
+// A worker without tasks backs off for a few attempts, then parks until work is
+// pushed or the executor stops
+func (e *WorkStealingExecutor[T, U]) runWorker(me int) {
+	defer e.wg.Done()
+	spins := 0
+	var idleSince time.Time
+	defer func() {
+		if spins > 0 {
+			e.workers[me].idle.Add(int64(time.Since(idleSince)))
+		}
+	}()
+	for !e.stopped() {
+		// Note the epoch before looking for work, so a push after the search
+		// wakes the worker if it parks
+		epoch := e.epoch.Load()
+		j := e.workers[me].queue.PopBottom()
+		if j == nil {
+			j = e.takeInjected(me)
+		}
+		if j == nil {
+			j = e.steal(me)
+		}
+		if j != nil {
+			if spins > 0 {
+				e.workers[me].idle.Add(int64(time.Since(idleSince)))
+				spins = 0
+			}
+			j.run()
+			j.complete()
+			e.workers[me].tasks.Add(1)
+			if e.pending.Add(-1) == 0 {
+				select {
+				case e.idle <- nil:
+				default:
 				}
 			}
+			continue
+		}
 
-			task := e.workers[me].queue.PopBottom()
-			if task != nil {
-				task.Run()
-				e.wg.Done()
-			}
+		if spins == 0 {
+			idleSince = time.Now()
+		}
+		spins++
+		if spins < idleSpins {
+			runtime.Gosched()
+		} else {
+			e.park(epoch)
 		}
 	}
 }
 
-// Shuts down the executor
-func (e *WorkStealingExecutor) Shutdown() {
-	e.wg.Wait()
+// Take up to threshold tasks submitted while running, returns one of them and
+// keeps the rest in the worker's queue
+func (e *WorkStealingExecutor[T, U]) takeInjected(me int) *job[T] {
+	jobs := e.injected.take(e.threshold)
+	if len(jobs) == 0 {
+		return nil
+	}
+	for _, j := range jobs[1:] {
+		e.workers[me].queue.PushBottom(j)
+	}
+	// Parked workers can steal the rest
+	if len(jobs) > 1 {
+		e.wake()
+	}
+	return jobs[0]
+}
+
+// Steal half of the tasks of another worker chosen by the steal policy, up to
+// threshold. Returns one of them and keeps the rest in the worker's queue
+func (e *WorkStealingExecutor[T, U]) steal(me int) *job[T] {
+	if len(e.workers) < 2 {
+		return nil
+	}
+	w := e.workers[me]
+	w.attempts.Add(1)
+	jobs := e.workers[e.victim(me)].queue.PopTopHalf(e.threshold)
+	if len(jobs) == 0 {
+		return nil
+	}
+	w.steals.Add(1)
+	w.stolen.Add(int64(len(jobs)))
+	if e.policy == LocalVictim {
+		w.cursor = 0
+	}
+	for _, j := range jobs[1:] {
+		w.queue.PushBottom(j)
+	}
+	// Parked workers can steal the rest
+	if len(jobs) > 1 {
+		e.wake()
+	}
+	return jobs[0]
+}
+
+// Check if the executor is shut down or its context is done
+func (e *WorkStealingExecutor[T, U]) stopped() bool {
+	select {
+	case <-e.shutdown:
+		return true
+	case <-e.ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// Park the worker until the epoch moves on from the one it saw
+func (e *WorkStealingExecutor[T, U]) park(epoch int64) {
+	e.mu.Lock()
+	e.parked.Add(1)
+	for e.epoch.Load() == epoch {
+		e.cond.Wait()
+	}
+	e.parked.Add(-1)
+	e.mu.Unlock()
+}
+
+// Move the epoch on and wake any parked workers. Parking workers count
+// themselves before checking the epoch, so either they see the new epoch or
+// the broadcast sees them
+func (e *WorkStealingExecutor[T, U]) wake() {
+	e.epoch.Add(1)
+	if e.parked.Load() > 0 {
+		e.mu.Lock()
+		e.cond.Broadcast()
+		e.mu.Unlock()
+	}
+}
+
+// Shuts down the executor once every task has run or the context is done,
+// cancelling the tasks left in the queues. The count of pending tasks detects
+// termination, as a task is counted from its submission until it has run, so
+// tasks may still submit further tasks while Shutdown waits. Without Execute
+// the submitted tasks are cancelled with ErrShutdown. Later calls wait for the
+// first to finish
+func (e *WorkStealingExecutor[T, U]) Shutdown() {
+	e.stop.Do(e.stopWorkers)
+}
+
+// Stop the workers and cancel the tasks they did not run
+func (e *WorkStealingExecutor[T, U]) stopWorkers() {
+	if e.running.Load() {
+	wait:
+		for e.pending.Load() > 0 {
+			select {
+			case <-e.idle:
+			case <-e.ctx.Done():
+				break wait
+			}
+		}
+	}
+	e.closing.Lock()
 	close(e.shutdown)
+	e.closing.Unlock()
+	e.wake()
+	e.wg.Wait()
+
+	err := e.ctx.Err()
+	if err == nil {
+		err = ErrShutdown
+	}
+	for _, worker := range e.workers {
+		for j := worker.queue.PopBottom(); j != nil; j = worker.queue.PopBottom() {
+			j.cancel(err)
+			j.complete()
+		}
+	}
+	for _, j := range e.injected.take(int(e.injected.size.Load())) {
+		j.cancel(err)
+		j.complete()
+	}
 }
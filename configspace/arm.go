@@ -0,0 +1,93 @@
+package configspace
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/fogleman/gg"
+)
+
+// Largest joint rotation between collision checks of the arm, in radians
+const armResolution = 0.02
+
+// PlanarArm is a CollisionChecker for a serial arm of revolute joints mounted
+// at a fixed base. Joint angles are relative to the previous link
+type PlanarArm struct {
+	Base      *Point     // Position of the first joint
+	Links     []float32  // Link lengths from base to tip
+	Obstacles []Obstacle // Obstacles the links must avoid
+}
+
+// Creates a new PlanarArm from its base position and link lengths
+func NewPlanarArm(config []string, obstacles []Obstacle) *PlanarArm {
+	x, _ := strconv.ParseFloat(config[0], 32)
+	y, _ := strconv.ParseFloat(config[1], 32)
+	var links []float32
+	for _, value := range config[2:] {
+		length, _ := strconv.ParseFloat(value, 32)
+		links = append(links, float32(length))
+	}
+	return &PlanarArm{
+		Base:      &Point{X: float32(x), Y: float32(y)},
+		Links:     links,
+		Obstacles: obstacles,
+	}
+}
+
+// Get the positions of the joints and the tip for the given joint angles
+func (a *PlanarArm) Joints(state *Point) []*Point {
+	joints := []*Point{a.Base}
+	x, y, angle := float64(a.Base.X), float64(a.Base.Y), 0.0
+	for i, length := range a.Links {
+		angle += float64(state.Coord(i))
+		x += float64(length) * math.Cos(angle)
+		y += float64(length) * math.Sin(angle)
+		joints = append(joints, &Point{X: float32(x), Y: float32(y)})
+	}
+	return joints
+}
+
+// Check if the arm's links hit an obstacle at the given joint angles
+func (a *PlanarArm) collides(state *Point) bool {
+	joints := a.Joints(state)
+	for i := 1; i < len(joints); i++ {
+		for _, o := range a.Obstacles {
+			if o.SegmentCollision(joints[i-1], joints[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Check the arm at joint angles interpolated between the two states
+func (a *PlanarArm) MotionValid(from *Point, to *Point) bool {
+	var maxStep float64
+	for i := range a.Links {
+		maxStep = math.Max(maxStep, math.Abs(float64(to.Coord(i)-from.Coord(i))))
+	}
+	steps := int(math.Ceil(maxStep / armResolution))
+	state := NewPointN(make([]float32, len(a.Links)))
+	for step := 0; step <= steps; step++ {
+		frac := float32(1)
+		if steps > 0 {
+			frac = float32(step) / float32(steps)
+		}
+		for i := range a.Links {
+			state.SetCoord(i, from.Coord(i)+frac*(to.Coord(i)-from.Coord(i)))
+		}
+		if a.collides(state) {
+			return false
+		}
+	}
+	return true
+}
+
+// Draw the arm's links at the given joint angles
+func (a *PlanarArm) Draw(screen *gg.Context, state *Point) {
+	joints := a.Joints(state)
+	screen.MoveTo(float64(joints[0].X), float64(joints[0].Y))
+	for _, joint := range joints[1:] {
+		screen.LineTo(float64(joint.X), float64(joint.Y))
+	}
+}
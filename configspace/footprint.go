@@ -0,0 +1,124 @@
+package configspace
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/fogleman/gg"
+)
+
+// Footprint is the polygonal outline of the robot in its own frame, with the
+// robot's heading along the positive X axis
+type Footprint struct {
+	Vertices []*Point // Polygon vertices in order
+}
+
+// Creates a new Footprint from a list of vertex coordinates
+func NewFootprint(config []string) *Footprint {
+	var vertices []*Point
+	for i := 0; i+1 < len(config); i += 2 {
+		x, _ := strconv.ParseFloat(config[i], 32)
+		y, _ := strconv.ParseFloat(config[i+1], 32)
+		vertices = append(vertices, &Point{X: float32(x), Y: float32(y)})
+	}
+	return &Footprint{Vertices: vertices}
+}
+
+// Radius of the smallest circle around the robot's origin containing the
+// footprint
+func (f *Footprint) Radius() float32 {
+	var radius float64
+	for _, v := range f.Vertices {
+		radius = math.Max(radius, math.Hypot(float64(v.X), float64(v.Y)))
+	}
+	return float32(radius)
+}
+
+// Get the footprint's vertices when the robot is at the given state
+func (f *Footprint) At(state *Point) []*Point {
+	sin, cos := math.Sincos(float64(state.Theta))
+	vertices := make([]*Point, len(f.Vertices))
+	for i, v := range f.Vertices {
+		vertices[i] = &Point{
+			X: state.X + float32(cos*float64(v.X)-sin*float64(v.Y)),
+			Y: state.Y + float32(sin*float64(v.X)+cos*float64(v.Y)),
+		}
+	}
+	return vertices
+}
+
+// Check if the footprint at the given state overlaps an obstacle, either
+// crossing its boundary or, for obstacles with an outline, one containing the
+// other
+func (f *Footprint) collides(state *Point, obstacles []Obstacle) bool {
+	vertices := f.At(state)
+	for i := range vertices {
+		next := vertices[(i+1)%len(vertices)]
+		for _, o := range obstacles {
+			if o.SegmentCollision(vertices[i], next) {
+				return true
+			}
+		}
+	}
+	for _, o := range obstacles {
+		if outliner, ok := o.(Outliner); ok && overlaps(vertices, outliner.Outline()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if either of two polygons whose edges do not cross has a vertex
+// inside the other, so one lies within the other
+func overlaps(a []*Point, b []*Point) bool {
+	for _, v := range b {
+		if insidePolygon(v, a) {
+			return true
+		}
+	}
+	for _, v := range a {
+		if insidePolygon(v, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if a point lies inside a polygon by counting the polygon's edges
+// crossed by a ray from the point along the positive X axis
+func insidePolygon(pt *Point, polygon []*Point) bool {
+	inside := false
+	for i := range polygon {
+		a, b := polygon[i], polygon[(i+1)%len(polygon)]
+		if (a.Y > pt.Y) != (b.Y > pt.Y) && pt.X < a.X+(pt.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Check if the footprint sweeps through an obstacle when moving between two
+// nearby states by following the motion of each vertex
+func (f *Footprint) sweepCollides(from *Point, to *Point, obstacles []Obstacle) bool {
+	start, end := f.At(from), f.At(to)
+	for i := range start {
+		for _, o := range obstacles {
+			if o.SegmentCollision(start[i], end[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Draw the footprint outline at the given state
+func (f *Footprint) Draw(screen *gg.Context, state *Point) {
+	for i, v := range f.At(state) {
+		if i == 0 {
+			screen.MoveTo(float64(v.X), float64(v.Y))
+		} else {
+			screen.LineTo(float64(v.X), float64(v.Y))
+		}
+	}
+	screen.ClosePath()
+}
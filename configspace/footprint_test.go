@@ -0,0 +1,67 @@
+package configspace
+
+// Unit testing for footprint.go. Tests the following functions:
+// NewFootprint
+// At
+// PathVisible
+// collides
+//
+
+import (
+	"math"
+	"testing"
+)
+
+// Test NewFootprint and At
+func TestFootprintAt(t *testing.T) {
+	f := NewFootprint([]string{"2", "0", "-2", "1", "-2", "-1"})
+	if len(f.Vertices) != 3 || f.Vertices[1].X != -2 || f.Vertices[1].Y != 1 {
+		t.Error("NewFootprint failed")
+	}
+	nose := f.At(&Point{X: 10, Y: 10, Theta: math.Pi / 2})[0]
+	if math.Abs(float64(nose.X-10)) > 1e-5 || math.Abs(float64(nose.Y-12)) > 1e-5 {
+		t.Errorf("Expected nose at (10, 12), got %+v", *nose)
+	}
+}
+
+// Test a long robot fits through a gap only when aligned with it
+func TestPathVisibleFootprint(t *testing.T) {
+	config := &Config{
+		Obstacles: []Obstacle{
+			NewRectangleObstacle([]string{"0", "9", "1", "9"}),
+			NewRectangleObstacle([]string{"11", "9", "1", "9"}),
+		},
+		Robot: NewFootprint([]string{"-3", "-0.5", "3", "-0.5", "3", "0.5", "-3", "0.5"}),
+	}
+	aligned := []*Point{{X: 10, Y: 5, Theta: math.Pi / 2}, {X: 10, Y: 15, Theta: math.Pi / 2}}
+	if !config.PathVisible(aligned) {
+		t.Error("Expected aligned robot to pass through gap")
+	}
+	across := []*Point{{X: 10, Y: 5}, {X: 10, Y: 15}}
+	if config.PathVisible(across) {
+		t.Error("Expected sideways robot to collide")
+	}
+	config.Robot = nil
+	if !config.PathVisible(across) {
+		t.Error("Expected point robot to pass through gap")
+	}
+}
+
+// Test obstacles inside the footprint and a footprint inside an obstacle
+// collide although no edges cross
+func TestPathVisibleContained(t *testing.T) {
+	config := &Config{
+		Obstacles: []Obstacle{NewRectangleObstacle([]string{"-1", "10.5", "2", "2"})},
+		Robot:     NewFootprint([]string{"-20", "-10", "20", "-10", "20", "10", "-20", "10"}),
+	}
+	if config.PathVisible([]*Point{{X: 0, Y: 0}, {X: 0, Y: 2.8}}) {
+		t.Error("Expected the robot to collide with an obstacle inside its footprint")
+	}
+	if !config.PathVisible([]*Point{{X: 0, Y: -2.8}, {X: 0, Y: 0}}) {
+		t.Error("Expected the robot to pass short of the obstacle")
+	}
+	config.Robot = NewFootprint([]string{"-0.5", "-0.5", "0.5", "-0.5", "0.5", "0.5", "-0.5", "0.5"})
+	if config.PathVisible([]*Point{{X: 0, Y: 11.5}, {X: 0.2, Y: 11.5}}) {
+		t.Error("Expected the robot to collide inside an obstacle")
+	}
+}
@@ -0,0 +1,66 @@
+package configspace
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// GenerateOptions describe a random configuration space
+type GenerateOptions struct {
+	Width      float32 // Window width
+	Height     float32 // Window height
+	Visibility float32 // Visibility radius
+	Obstacles  int     // Number of rectangles placed
+	MinSize    float32 // Smallest side of a rectangle
+	MaxSize    float32 // Largest side of a rectangle
+}
+
+// Attempts at placing each rectangle clear of the start and goal
+const placeAttempts = 100
+
+// Generate writes a config file for a window of randomly placed rectangles,
+// with the start and goal in opposite corners kept clear of them
+func Generate(w io.Writer, opts GenerateOptions, rng *rand.Rand) error {
+	if opts.Width <= 0 || opts.Height <= 0 {
+		return fmt.Errorf("window must have a positive size, got %gx%g", opts.Width, opts.Height)
+	}
+	if opts.Visibility <= 0 {
+		return fmt.Errorf("visibility must be positive, got %g", opts.Visibility)
+	}
+	if opts.Obstacles < 0 {
+		return fmt.Errorf("obstacles must not be negative, got %d", opts.Obstacles)
+	}
+	if opts.MinSize <= 0 || opts.MaxSize < opts.MinSize {
+		return fmt.Errorf("sizes must satisfy 0 < min <= max, got %g and %g", opts.MinSize, opts.MaxSize)
+	}
+
+	start := &Point{X: opts.Width * 0.05, Y: opts.Height * 0.05}
+	goal := &Point{X: opts.Width * 0.95, Y: opts.Height * 0.95}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "window,%g,%g\n", opts.Height, opts.Width)
+	fmt.Fprintf(out, "visibility,%g\n", opts.Visibility)
+	fmt.Fprintf(out, "start,%g,%g\n", start.X, start.Y)
+	fmt.Fprintf(out, "goal,%g,%g\n", goal.X, goal.Y)
+
+	// Rectangles are grown by a margin when checked so the start and goal are
+	// not boxed in
+	margin := opts.Visibility / 2
+	for i := 0; i < opts.Obstacles; i++ {
+		for attempt := 0; attempt < placeAttempts; attempt++ {
+			wd := opts.MinSize + rng.Float32()*(opts.MaxSize-opts.MinSize)
+			ht := opts.MinSize + rng.Float32()*(opts.MaxSize-opts.MinSize)
+			x := rng.Float32() * (opts.Width - wd)
+			y := rng.Float32() * (opts.Height - ht)
+			grown := &rectangleObstacle{&Point{X: x - margin, Y: y - margin}, wd + 2*margin, ht + 2*margin}
+			if grown.contains(start) || grown.contains(goal) {
+				continue
+			}
+			fmt.Fprintf(out, "rectangle,%g,%g,%g,%g\n", x, y, ht, wd)
+			break
+		}
+	}
+	return out.Flush()
+}
@@ -0,0 +1,24 @@
+package configspace
+
+// Benchmarks for intersection.go. Benchmarks the following functions:
+// Intersection
+//
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Benchmark Intersection on random segments in a unit square
+func BenchmarkIntersection(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	pts := make([]*Point, 1024)
+	for i := range pts {
+		pts[i] = &Point{X: rng.Float32(), Y: rng.Float32()}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		j := i % (len(pts) - 3)
+		Intersection(pts[j], pts[j+1], pts[j+2], pts[j+3])
+	}
+}
@@ -0,0 +1,139 @@
+package configspace
+
+import (
+	"image/color"
+	"strconv"
+
+	"github.com/fogleman/gg"
+)
+
+// TimedObstacle is an obstacle whose position changes over time
+type TimedObstacle interface {
+	// SegmentCollisionAt detects a collision with a robot moving from pt1 at
+	// time t1 to pt2 at time t2 at constant speed
+	SegmentCollisionAt(pt1 *Point, pt2 *Point, t1 float32, t2 float32) bool
+	DrawAt(*gg.Context, float32)
+	Draw(*gg.Context)
+}
+
+// waypoint is the position of a moving obstacle at a given time
+type waypoint struct {
+	t float32 // Time the obstacle reaches the position
+	x float32 // X position of the obstacle's lower corner
+	y float32 // Y position of the obstacle's lower corner
+}
+
+// movingRectangleObstacle implements a TimedObstacle that moves linearly
+// between waypoints and waits at its first and last waypoints
+type movingRectangleObstacle struct {
+	shape     *rectangleObstacle // Rectangle with its lower corner at the origin
+	waypoints []waypoint         // Schedule of the obstacle, sorted by time
+}
+
+// Creates a new rectangle moving at constant velocity from time zero, the
+// config is the rectangle's position, height, width and velocity
+func NewMovingRectangleObstacle(config []string, horizon float32) TimedObstacle {
+	var values [6]float32
+	for i := range values {
+		value, _ := strconv.ParseFloat(config[i], 32)
+		values[i] = float32(value)
+	}
+	x, y, h, w, vx, vy := values[0], values[1], values[2], values[3], values[4], values[5]
+	return &movingRectangleObstacle{
+		shape: &rectangleObstacle{&Point{}, w, h},
+		waypoints: []waypoint{
+			{0, x, y},
+			{horizon, x + vx*horizon, y + vy*horizon},
+		},
+	}
+}
+
+// Creates a new rectangle following a schedule, the config is the rectangle's
+// height and width followed by triples of time and position
+func NewScheduledRectangleObstacle(config []string) TimedObstacle {
+	h, _ := strconv.ParseFloat(config[0], 32)
+	w, _ := strconv.ParseFloat(config[1], 32)
+	var waypoints []waypoint
+	for i := 2; i+2 < len(config); i += 3 {
+		t, _ := strconv.ParseFloat(config[i], 32)
+		x, _ := strconv.ParseFloat(config[i+1], 32)
+		y, _ := strconv.ParseFloat(config[i+2], 32)
+		waypoints = append(waypoints, waypoint{float32(t), float32(x), float32(y)})
+	}
+	return &movingRectangleObstacle{
+		shape:     &rectangleObstacle{&Point{}, float32(w), float32(h)},
+		waypoints: waypoints,
+	}
+}
+
+// Position of the obstacle's lower corner at time t
+func (m *movingRectangleObstacle) offset(t float32) (float32, float32) {
+	first, last := m.waypoints[0], m.waypoints[len(m.waypoints)-1]
+	if t <= first.t {
+		return first.x, first.y
+	}
+	for i := 1; i < len(m.waypoints); i++ {
+		prev, next := m.waypoints[i-1], m.waypoints[i]
+		if t <= next.t {
+			frac := (t - prev.t) / (next.t - prev.t)
+			return prev.x + frac*(next.x-prev.x), prev.y + frac*(next.y-prev.y)
+		}
+	}
+	return last.x, last.y
+}
+
+// Detect a collision by splitting the time interval at the schedule's
+// waypoints, over each piece both the robot and obstacle move linearly so the
+// robot's motion relative to the obstacle is a segment
+func (m *movingRectangleObstacle) SegmentCollisionAt(pt1 *Point, pt2 *Point,
+	t1 float32, t2 float32,
+) bool {
+	times := []float32{t1}
+	for _, wp := range m.waypoints {
+		if wp.t > t1 && wp.t < t2 {
+			times = append(times, wp.t)
+		}
+	}
+	times = append(times, t2)
+
+	// Robot's position relative to the obstacle at time t
+	relative := func(t float32) *Point {
+		frac := float32(0)
+		if t2 > t1 {
+			frac = (t - t1) / (t2 - t1)
+		}
+		ox, oy := m.offset(t)
+		return &Point{X: pt1.X + frac*(pt2.X-pt1.X) - ox, Y: pt1.Y + frac*(pt2.Y-pt1.Y) - oy}
+	}
+
+	start := relative(times[0])
+	if m.shape.contains(start) {
+		return true
+	}
+	for _, t := range times[1:] {
+		end := relative(t)
+		if m.shape.SegmentCollision(start, end) || m.shape.contains(end) {
+			return true
+		}
+		start = end
+	}
+	return false
+}
+
+// Draw the obstacle at time t
+func (m *movingRectangleObstacle) DrawAt(screen *gg.Context, t float32) {
+	x, y := m.offset(t)
+	screen.DrawRectangle(float64(x), float64(y), float64(m.shape.w), float64(m.shape.h))
+	screen.Fill()
+}
+
+// Draw the obstacle's route and its starting position
+func (m *movingRectangleObstacle) Draw(screen *gg.Context) {
+	screen.SetColor(color.Gray{Y: 160})
+	screen.SetLineWidth(3.0)
+	for _, wp := range m.waypoints {
+		screen.LineTo(float64(wp.x+m.shape.w/2), float64(wp.y+m.shape.h/2))
+	}
+	screen.Stroke()
+	m.DrawAt(screen, 0)
+}
@@ -0,0 +1,57 @@
+package configspace
+
+// Unit testing for moving.go. Tests the following functions:
+// NewMovingRectangleObstacle
+// NewScheduledRectangleObstacle
+// SegmentCollisionAt
+// PathVisibleAt
+//
+
+import (
+	"testing"
+)
+
+// Test a robot crossing the lane of a moving rectangle
+func TestMovingSegmentCollisionAt(t *testing.T) {
+	// 2x2 rectangle moving right along y in [4, 6] at 1 unit per second
+	o := NewMovingRectangleObstacle([]string{"0", "4", "2", "2", "1", "0"}, 100)
+	pt1, pt2 := &Point{X: 5, Y: 0}, &Point{X: 5, Y: 10}
+	// Rectangle covers x in [4, 6] during t in [4, 6]
+	if !o.SegmentCollisionAt(pt1, pt2, 0, 10) {
+		t.Error("Expected collision when crossing as the rectangle passes")
+	}
+	if o.SegmentCollisionAt(pt1, pt2, 10, 20) {
+		t.Error("Expected no collision after the rectangle has passed")
+	}
+}
+
+// Test a robot waiting inside a rectangle's schedule is hit
+func TestScheduledSegmentCollisionAt(t *testing.T) {
+	o := NewScheduledRectangleObstacle([]string{"2", "2", "0", "0", "0", "5", "0", "0", "6", "10", "10"})
+	pt := &Point{X: 5, Y: 5}
+	if o.SegmentCollisionAt(pt, pt, 0, 5) {
+		t.Error("Expected no collision while the rectangle waits")
+	}
+	if !o.SegmentCollisionAt(pt, pt, 5, 6) {
+		t.Error("Expected collision as the rectangle moves over the robot")
+	}
+	if o.SegmentCollisionAt(pt, pt, 7, 8) {
+		t.Error("Expected no collision once the rectangle has parked")
+	}
+}
+
+// Test PathVisibleAt spreads time over the states
+func TestPathVisibleAt(t *testing.T) {
+	config := &Config{
+		Moving: []TimedObstacle{
+			NewScheduledRectangleObstacle([]string{"2", "2", "0", "9", "0", "100", "9", "0"}),
+		},
+	}
+	states := []*Point{{X: 0, Y: 1}, {X: 5, Y: 1}, {X: 10, Y: 1}}
+	if config.PathVisibleAt(states, 0, 10) {
+		t.Error("Expected collision at the end of the path")
+	}
+	if !config.PathVisibleAt(states[:2], 0, 5) {
+		t.Error("Expected first half of the path to be clear")
+	}
+}
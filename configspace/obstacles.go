@@ -13,6 +13,12 @@ type Obstacle interface {
 	Draw(*gg.Context)
 }
 
+// Outliner is implemented by obstacles that can give their top view outline
+// as a polygon, used when exporting vector formats
+type Outliner interface {
+	Outline() []*Point
+}
+
 // rectangleObstacle implements an Obstacle
 type rectangleObstacle struct {
 	pt *Point
@@ -28,7 +34,7 @@ func NewRectangleObstacle(config []string) Obstacle {
 	w, _ := strconv.ParseFloat(config[3], 32)
 
 	return &rectangleObstacle{
-		&Point{float32(x), float32(y)},
+		&Point{X: float32(x), Y: float32(y)},
 		float32(w),
 		float32(h),
 	}
@@ -37,8 +43,8 @@ func NewRectangleObstacle(config []string) Obstacle {
 // Detect obstacle's collision with the line segment described by the two points
 func (r *rectangleObstacle) SegmentCollision(pt1 *Point, pt2 *Point) bool {
 	// Get line segments that make up rectangle
-	ll, lr := &Point{r.pt.X, r.pt.Y}, &Point{r.pt.X + r.w, r.pt.Y}
-	ul, ur := &Point{r.pt.X, r.pt.Y + r.h}, &Point{r.pt.X + r.w, r.pt.Y + r.h}
+	ll, lr := &Point{X: r.pt.X, Y: r.pt.Y}, &Point{X: r.pt.X + r.w, Y: r.pt.Y}
+	ul, ur := &Point{X: r.pt.X, Y: r.pt.Y + r.h}, &Point{X: r.pt.X + r.w, Y: r.pt.Y + r.h}
 
 	rectangleSegments := [][]*Point{
 		{ll, ul}, {ul, ur},
@@ -54,6 +60,19 @@ func (r *rectangleObstacle) SegmentCollision(pt1 *Point, pt2 *Point) bool {
 	return false
 }
 
+// Check if a point lies strictly inside the rectangle
+func (r *rectangleObstacle) contains(pt *Point) bool {
+	return pt.X > r.pt.X && pt.X < r.pt.X+r.w && pt.Y > r.pt.Y && pt.Y < r.pt.Y+r.h
+}
+
+// Get the rectangle's corners counterclockwise from its origin
+func (r *rectangleObstacle) Outline() []*Point {
+	return []*Point{
+		{X: r.pt.X, Y: r.pt.Y}, {X: r.pt.X + r.w, Y: r.pt.Y},
+		{X: r.pt.X + r.w, Y: r.pt.Y + r.h}, {X: r.pt.X, Y: r.pt.Y + r.h},
+	}
+}
+
 // Draw the obstacle onto the screen
 func (r *rectangleObstacle) Draw(screen *gg.Context) {
 	// Draw the image
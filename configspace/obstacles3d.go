@@ -0,0 +1,164 @@
+// Segment-box test uses the slab method described in Williams et al., "An
+// Efficient and Robust Ray-Box Intersection Algorithm"
+
+package configspace
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/fogleman/gg"
+)
+
+// Projector is implemented by obstacles that can be drawn onto the plane
+// spanned by two coordinate axes
+type Projector interface {
+	DrawProjection(screen *gg.Context, u int, v int)
+}
+
+// boxObstacle implements an axis-aligned box Obstacle in 3D
+type boxObstacle struct {
+	min [3]float64 // Minimum corner
+	max [3]float64 // Maximum corner
+}
+
+// Creates a new boxObstacle Obstacle from its minimum corner and size
+func NewBoxObstacle(config []string) Obstacle {
+	var values [6]float64
+	for i := range values {
+		values[i], _ = strconv.ParseFloat(config[i], 32)
+	}
+	return &boxObstacle{
+		min: [3]float64{values[0], values[1], values[2]},
+		max: [3]float64{values[0] + values[3], values[1] + values[4], values[2] + values[5]},
+	}
+}
+
+// Detect the box's collision with the segment by clipping it against each
+// pair of parallel faces. Planar segments are checked against the top view
+func (b *boxObstacle) SegmentCollision(pt1 *Point, pt2 *Point) bool {
+	tMin, tMax := 0.0, 1.0
+	for axis := 0; axis < solidAxes(pt1, pt2); axis++ {
+		start := float64(pt1.Coord(axis))
+		delta := float64(pt2.Coord(axis)) - start
+		if delta == 0 {
+			// Parallel to the slab, must already lie within it
+			if start < b.min[axis] || start > b.max[axis] {
+				return false
+			}
+			continue
+		}
+		t1 := (b.min[axis] - start) / delta
+		t2 := (b.max[axis] - start) / delta
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		tMin, tMax = math.Max(tMin, t1), math.Min(tMax, t2)
+		if tMin > tMax {
+			return false
+		}
+	}
+	return true
+}
+
+// Draw the box's top view
+func (b *boxObstacle) Draw(screen *gg.Context) {
+	b.DrawProjection(screen, 0, 1)
+}
+
+// Draw the box projected onto two axes
+func (b *boxObstacle) DrawProjection(screen *gg.Context, u int, v int) {
+	screen.SetColor(color.Black)
+	screen.DrawRectangle(b.min[u], b.min[v], b.max[u]-b.min[u], b.max[v]-b.min[v])
+	screen.Fill()
+}
+
+// Get the corners of the box's top view
+func (b *boxObstacle) Outline() []*Point {
+	x0, y0, x1, y1 := float32(b.min[0]), float32(b.min[1]), float32(b.max[0]), float32(b.max[1])
+	return []*Point{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}}
+}
+
+// Get the number of axes of a segment a solid obstacle is checked on, at most 3
+func solidAxes(pt1 *Point, pt2 *Point) int {
+	axes := 3
+	if pt1.Dim() < axes {
+		axes = pt1.Dim()
+	}
+	if pt2.Dim() < axes {
+		axes = pt2.Dim()
+	}
+	return axes
+}
+
+// sphereObstacle implements a sphere Obstacle in 3D
+type sphereObstacle struct {
+	center [3]float64 // Center of the sphere
+	radius float64    // Radius of the sphere
+}
+
+// Creates a new sphereObstacle Obstacle from its center and radius
+func NewSphereObstacle(config []string) Obstacle {
+	var values [4]float64
+	for i := range values {
+		values[i], _ = strconv.ParseFloat(config[i], 32)
+	}
+	return &sphereObstacle{
+		center: [3]float64{values[0], values[1], values[2]},
+		radius: values[3],
+	}
+}
+
+// Detect the sphere's collision with the segment by finding the segment's
+// closest point to the center. Planar segments are checked against the top
+// view
+func (s *sphereObstacle) SegmentCollision(pt1 *Point, pt2 *Point) bool {
+	var dir, toCenter [3]float64
+	var lenSq, proj float64
+	axes := solidAxes(pt1, pt2)
+	for axis := 0; axis < axes; axis++ {
+		dir[axis] = float64(pt2.Coord(axis) - pt1.Coord(axis))
+		toCenter[axis] = s.center[axis] - float64(pt1.Coord(axis))
+		lenSq += dir[axis] * dir[axis]
+		proj += dir[axis] * toCenter[axis]
+	}
+	t := 0.0
+	if lenSq > 0 {
+		t = math.Max(0, math.Min(1, proj/lenSq))
+	}
+	var distSq float64
+	for axis := 0; axis < axes; axis++ {
+		diff := toCenter[axis] - t*dir[axis]
+		distSq += diff * diff
+	}
+	return distSq <= s.radius*s.radius
+}
+
+// Number of sides of the polygon approximating a sphere's outline
+const sphereSides = 32
+
+// Get a polygon approximating the sphere's top view
+func (s *sphereObstacle) Outline() []*Point {
+	outline := make([]*Point, sphereSides)
+	for i := range outline {
+		angle := 2 * math.Pi * float64(i) / sphereSides
+		outline[i] = &Point{
+			X: float32(s.center[0] + s.radius*math.Cos(angle)),
+			Y: float32(s.center[1] + s.radius*math.Sin(angle)),
+		}
+	}
+	return outline
+}
+
+// Draw the sphere's top view
+func (s *sphereObstacle) Draw(screen *gg.Context) {
+	s.DrawProjection(screen, 0, 1)
+}
+
+// Draw the sphere projected onto two axes
+func (s *sphereObstacle) DrawProjection(screen *gg.Context, u int, v int) {
+	screen.SetColor(color.Black)
+	screen.DrawCircle(s.center[u], s.center[v], s.radius)
+	screen.Fill()
+}
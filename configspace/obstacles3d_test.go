@@ -0,0 +1,55 @@
+package configspace
+
+// Unit testing for obstacles3d.go. Tests the following functions:
+// boxObstacle SegmentCollision
+// sphereObstacle SegmentCollision
+//
+
+import (
+	"testing"
+)
+
+// Test segments through, beside and above a box
+func TestBoxSegmentCollision(t *testing.T) {
+	box := NewBoxObstacle([]string{"1", "1", "1", "1", "1", "1"})
+	through := []*Point{NewPointN([]float32{0, 0, 0}), NewPointN([]float32{3, 3, 3})}
+	if !box.SegmentCollision(through[0], through[1]) {
+		t.Error("Expected diagonal segment to hit box")
+	}
+	above := []*Point{NewPointN([]float32{0, 1.5, 2.5}), NewPointN([]float32{3, 1.5, 2.5})}
+	if box.SegmentCollision(above[0], above[1]) {
+		t.Error("Expected segment above box to miss")
+	}
+	short := []*Point{NewPointN([]float32{0, 1.5, 1.5}), NewPointN([]float32{0.9, 1.5, 1.5})}
+	if box.SegmentCollision(short[0], short[1]) {
+		t.Error("Expected segment ending before box to miss")
+	}
+}
+
+// Test segments passing near a sphere
+func TestSphereSegmentCollision(t *testing.T) {
+	sphere := NewSphereObstacle([]string{"0", "0", "0", "1"})
+	if !sphere.SegmentCollision(NewPointN([]float32{-2, 0, 0.5}), NewPointN([]float32{2, 0, 0.5})) {
+		t.Error("Expected segment through sphere to hit")
+	}
+	if sphere.SegmentCollision(NewPointN([]float32{-2, 0, 1.5}), NewPointN([]float32{2, 0, 1.5})) {
+		t.Error("Expected segment above sphere to miss")
+	}
+	if sphere.SegmentCollision(NewPointN([]float32{2, 0, 0}), NewPointN([]float32{3, 0, 0})) {
+		t.Error("Expected segment pointing away from sphere to miss")
+	}
+}
+
+// Test planar segments are checked against the top views of solid obstacles
+func TestSolidSegmentCollisionPlanar(t *testing.T) {
+	box := NewBoxObstacle([]string{"100", "100", "0", "50", "50", "50"})
+	sphere := NewSphereObstacle([]string{"100", "100", "0", "10"})
+	through := []*Point{{X: 90, Y: 90}, {X: 120, Y: 120}}
+	beside := []*Point{{X: 90, Y: 160}, {X: 160, Y: 160}}
+	if !box.SegmentCollision(through[0], through[1]) || box.SegmentCollision(beside[0], beside[1]) {
+		t.Error("Expected a planar segment to hit only the box's top view")
+	}
+	if !sphere.SegmentCollision(through[0], through[1]) || sphere.SegmentCollision(beside[0], beside[1]) {
+		t.Error("Expected a planar segment to hit only the sphere's top view")
+	}
+}
@@ -12,7 +12,7 @@ import (
 
 // Test NewPoint
 func TestNewPoint(t *testing.T) {
-	pt := Point{1.0, 2.0}
+	pt := Point{X: 1.0, Y: 2.0}
 	if pt.X != 1.0 || pt.Y != 2.0 {
 		t.Error("NewPoint failed")
 	}
@@ -20,7 +20,7 @@ func TestNewPoint(t *testing.T) {
 
 // Test NewRectangleObstacle
 func TestNewRectangleObstacle(t *testing.T) {
-	rect := NewRectangleObstacle([]string{"0.0", "0.0", "0.5", "0.5"})
+	rect := NewRectangleObstacle([]string{"0.0", "0.0", "0.5", "0.5"}).(*rectangleObstacle)
 	if rect.pt.X != 0.0 || rect.pt.Y != 0.0 || rect.w != 0.5 || rect.h != 0.5 {
 		t.Error("NewRectangleObstacle failed")
 	}
@@ -29,8 +29,8 @@ func TestNewRectangleObstacle(t *testing.T) {
 // Test SegmentCollision
 func TestSegmentCollisionDiagonalThrough(t *testing.T) {
 	rect := NewRectangleObstacle([]string{"1.0", "1.0", "0.5", "0.5"})
-	pt1 := &Point{0.0, 0.0}
-	pt2 := &Point{2.0, 2.0}
+	pt1 := &Point{X: 0.0, Y: 0.0}
+	pt2 := &Point{X: 2.0, Y: 2.0}
 	if !rect.SegmentCollision(pt1, pt2) {
 		t.Error("SegmentCollision failed")
 	}
@@ -38,8 +38,8 @@ func TestSegmentCollisionDiagonalThrough(t *testing.T) {
 
 func TestSegmentCollisionCollinear(t *testing.T) {
 	rect := NewRectangleObstacle([]string{"0.0", "0.5", "0.5", "0.5"})
-	pt1 := &Point{1.0, 0.5}
-	pt2 := &Point{1.5, 0.5}
+	pt1 := &Point{X: 1.0, Y: 0.5}
+	pt2 := &Point{X: 1.5, Y: 0.5}
 	if rect.SegmentCollision(pt1, pt2) {
 		t.Error("SegmentCollision failed")
 	}
@@ -47,13 +47,13 @@ func TestSegmentCollisionCollinear(t *testing.T) {
 
 func TestSegmentCollisionEndPoint(t *testing.T) {
 	rect := NewRectangleObstacle([]string{"0.0", "0.5", "1.0", "1.0"})
-	pt1 := &Point{0.5, 0.0}
-	pt2 := &Point{0.5, 0.5}
+	pt1 := &Point{X: 0.5, Y: 0.0}
+	pt2 := &Point{X: 0.5, Y: 0.5}
 	if !rect.SegmentCollision(pt1, pt2) {
 		t.Error("SegmentCollision failed")
 	}
-	pt1 = &Point{0.5, 0.0}
-	pt2 = &Point{0.5, 0.25}
+	pt1 = &Point{X: 0.5, Y: 0.0}
+	pt2 = &Point{X: 0.5, Y: 0.25}
 	if rect.SegmentCollision(pt1, pt2) {
 		t.Error("SegmentCollision failed")
 	}
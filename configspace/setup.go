@@ -1,6 +1,7 @@
 package configspace
 
 import (
+	"math"
 	"strconv"
 	"strings"
 
@@ -9,26 +10,51 @@ import (
 
 // ConfigSpace is a struct representing the configuration space
 type Config struct {
-	Start      *Point     // Start point
-	Goal       *Point     // Goal point
-	Visibility float32    // Visibility radius
-	Obstacles  []Obstacle // Obstacles in the configuration space
-	WinHeight  float32    // Window height
-	WinWidth   float32    // Window width
+	Start      *Point           // Start point
+	Goal       *Point           // Goal point
+	Visibility float32          // Visibility radius
+	Obstacles  []Obstacle       // Obstacles in the configuration space
+	WinHeight  float32          // Window height
+	WinWidth   float32          // Window width
+	WinDepth   float32          // Window depth, zero for planar spaces
+	Robot      *Footprint       // Robot footprint, nil for a point robot
+	Steering   string           // Steering method of the robot
+	TurnRadius float32          // Minimum turning radius for car-like steering
+	RotWeight  float32          // Weight of rotation in the SE(2) distance metric
+	MaxAcc     float32          // Maximum acceleration for kinodynamic steering
+	MaxVel     float32          // Maximum velocity for kinodynamic steering
+	TimeStep   float32          // Duration of each kinodynamic extension
+	Limits     []Limit          // Joint limits, overrides the window as sampling bounds
+	Checker    CollisionChecker // Custom collision checker, nil for built-in checks
+	Moving     []TimedObstacle  // Obstacles moving on known schedules
+	Speed      float32          // Robot speed used to time milestones
 }
 
 // Point is a general struct used for points
 type Point struct {
-	X float32
-	Y float32
+	X     float32
+	Y     float32
+	Theta float32   // Heading in radians, used by car-like robots
+	Q     []float32 // Coordinates beyond X and Y in N-dimensional spaces
 }
 
+// Time until which obstacles moving at constant velocity are tracked
+const defaultHorizon = 1000
+
 // Create a new configuration space from a config file
 func NewConfigSpace(configPath string) *Config {
 
 	// Initialize space's variables
-	var winWidth, winHeight, radius float64
+	var winWidth, winHeight, winDepth, radius, turnRadius, rotWeight float64
+	var maxAcc, maxVel, timeStep float64
+	var speed, horizon float64
+	var movingConfig [][]string
+	var moving []TimedObstacle
 	var start, goal *Point
+	var startConfig, goalConfig, armConfig []string
+	var robot *Footprint
+	var steering string
+	var limits []Limit
 	var obstacles []Obstacle
 
 	// Parse config file
@@ -42,24 +68,106 @@ func NewConfigSpace(configPath string) *Config {
 			winHeight, _ = strconv.ParseFloat(line[1], 32)
 			winWidth, _ = strconv.ParseFloat(line[2], 32)
 
+		} else if line[0] == "depth" {
+			winDepth, _ = strconv.ParseFloat(line[1], 32)
+
 		} else if line[0] == "visibility" {
 			radius, _ = strconv.ParseFloat(line[1], 32)
 
 		} else if line[0] == "start" {
-			x, _ := strconv.ParseFloat(line[1], 32)
-			y, _ := strconv.ParseFloat(line[2], 32)
-			start = &Point{float32(x), float32(y)}
+			startConfig = line[1:]
 
 		} else if line[0] == "goal" {
-			x, _ := strconv.ParseFloat(line[1], 32)
-			y, _ := strconv.ParseFloat(line[2], 32)
-			goal = &Point{float32(x), float32(y)}
+			goalConfig = line[1:]
+
+		} else if line[0] == "joint" {
+			limits = append(limits, parseLimit(line[1:]))
+
+		} else if line[0] == "arm" {
+			armConfig = line[1:]
+
+		} else if line[0] == "robot" {
+			robot = NewFootprint(line[1:])
+
+		} else if line[0] == "steering" {
+			// Parameters depend on the steering method
+			steering = line[1]
+			if len(line) > 2 && steering == "se2" {
+				rotWeight, _ = strconv.ParseFloat(line[2], 32)
+			} else if len(line) > 4 && steering == "doubleintegrator" {
+				maxAcc, _ = strconv.ParseFloat(line[2], 32)
+				maxVel, _ = strconv.ParseFloat(line[3], 32)
+				timeStep, _ = strconv.ParseFloat(line[4], 32)
+			} else if len(line) > 2 {
+				turnRadius, _ = strconv.ParseFloat(line[2], 32)
+			}
 
 		} else if line[0] == "rectangle" {
 			obstacles = append(obstacles, NewRectangleObstacle(line[1:]))
+
+		} else if line[0] == "speed" {
+			speed, _ = strconv.ParseFloat(line[1], 32)
+
+		} else if line[0] == "horizon" {
+			horizon, _ = strconv.ParseFloat(line[1], 32)
+
+		} else if line[0] == "movingrectangle" {
+			movingConfig = append(movingConfig, line[1:])
+
+		} else if line[0] == "scheduledrectangle" {
+			moving = append(moving, NewScheduledRectangleObstacle(line[1:]))
+
+		} else if line[0] == "box" {
+			obstacles = append(obstacles, NewBoxObstacle(line[1:]))
+
+		} else if line[0] == "sphere" {
+			obstacles = append(obstacles, NewSphereObstacle(line[1:]))
+		}
+	}
+
+	// A window with depth is a 3D workspace
+	if winDepth > 0 && len(limits) == 0 {
+		limits = []Limit{
+			{0, float32(winWidth)}, {0, float32(winHeight)}, {0, float32(winDepth)},
 		}
 	}
 
+	// Kinodynamic states carry a velocity along each axis of the window
+	if steering == "doubleintegrator" && len(limits) == 0 {
+		velLimit := Limit{float32(-maxVel), float32(maxVel)}
+		limits = []Limit{{0, float32(winWidth)}, {0, float32(winHeight)}, velLimit, velLimit}
+	}
+
+	// Milestone times are their costs when no speed is given, and obstacles
+	// moving at constant velocity keep going until the horizon
+	if speed == 0 {
+		speed = 1
+	}
+	if horizon == 0 {
+		horizon = defaultHorizon
+	}
+	for _, config := range movingConfig {
+		moving = append(moving, NewMovingRectangleObstacle(config, float32(horizon)))
+	}
+
+	// Arms plan in joint space, which defaults to a full turn for each joint
+	var checker CollisionChecker
+	if armConfig != nil {
+		arm := NewPlanarArm(armConfig, obstacles)
+		checker = arm
+		for len(limits) < len(arm.Links) {
+			limits = append(limits, revoluteLimit)
+		}
+	}
+
+	// Start and goal are joint coordinates in N-dimensional spaces, otherwise
+	// a planar position with an optional heading
+	if len(limits) > 0 {
+		start, goal = parseCoords(startConfig, len(limits)), parseCoords(goalConfig, len(limits))
+	} else if startConfig != nil && goalConfig != nil {
+		start, goal = parsePose(startConfig), parsePose(goalConfig)
+	}
+
 	return &Config{
 		Start:      start,
 		Goal:       goal,
@@ -67,12 +175,59 @@ func NewConfigSpace(configPath string) *Config {
 		Obstacles:  obstacles,
 		WinHeight:  float32(winHeight),
 		WinWidth:   float32(winWidth),
+		WinDepth:   float32(winDepth),
+		Robot:      robot,
+		Steering:   steering,
+		TurnRadius: float32(turnRadius),
+		RotWeight:  float32(rotWeight),
+		MaxAcc:     float32(maxAcc),
+		MaxVel:     float32(maxVel),
+		TimeStep:   float32(timeStep),
+		Limits:     limits,
+		Checker:    checker,
+		Moving:     moving,
+		Speed:      float32(speed),
+	}
+}
+
+// Parse a point with an optional heading given in degrees
+func parsePose(config []string) *Point {
+	x, _ := strconv.ParseFloat(config[0], 32)
+	y, _ := strconv.ParseFloat(config[1], 32)
+	var heading float64
+	if len(config) > 2 {
+		heading, _ = strconv.ParseFloat(config[2], 32)
 	}
+	return &Point{X: float32(x), Y: float32(y), Theta: float32(heading * math.Pi / 180)}
 }
 
 // NewPoint creates a new Point
 func (c *Config) NewPoint(x, y float32) *Point {
-	return &Point{x, y}
+	return &Point{X: x, Y: y}
+}
+
+// Add an obstacle to the configuration space, the arm's collision checker is
+// kept in sync
+func (c *Config) AddObstacle(o Obstacle) {
+	c.Obstacles = append(c.Obstacles, o)
+	if arm, ok := c.Checker.(*PlanarArm); ok {
+		arm.Obstacles = c.Obstacles
+	}
+}
+
+// Remove an obstacle from the configuration space, returns false if it was
+// not found
+func (c *Config) RemoveObstacle(o Obstacle) bool {
+	for i, other := range c.Obstacles {
+		if other == o {
+			c.Obstacles = append(c.Obstacles[:i:i], c.Obstacles[i+1:]...)
+			if arm, ok := c.Checker.(*PlanarArm); ok {
+				arm.Obstacles = c.Obstacles
+			}
+			return true
+		}
+	}
+	return false
 }
 
 // Check if a new path branch (line segment) is not obstructed by any obstacle
@@ -85,9 +240,90 @@ func (c *Config) Visible(pt1 *Point, pt2 *Point) bool {
 	return true
 }
 
+// Check if a local path, given as a sequence of states, is not obstructed by
+// any obstacle. Robots with a footprint are checked at every state and along
+// the sweep between consecutive states
+func (c *Config) PathVisible(states []*Point) bool {
+	if c.Checker != nil {
+		for i := 1; i < len(states); i++ {
+			if !c.Checker.MotionValid(states[i-1], states[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if c.Robot != nil {
+		for i, state := range states {
+			if c.Robot.collides(state, c.Obstacles) {
+				return false
+			}
+			if i > 0 && c.Robot.sweepCollides(states[i-1], state, c.Obstacles) {
+				return false
+			}
+		}
+		return true
+	}
+	for i := 1; i < len(states); i++ {
+		if !c.Visible(states[i-1], states[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Check if a local path is not obstructed when the robot enters it at time t1
+// and leaves at time t2, with moving obstacles checked at the times the robot
+// passes each state
+func (c *Config) PathVisibleAt(states []*Point, t1 float32, t2 float32) bool {
+	if !c.PathVisible(states) {
+		return false
+	}
+	if len(c.Moving) == 0 {
+		return true
+	}
+
+	// Spread the time over the states by distance travelled
+	lengths := make([]float32, len(states))
+	for i := 1; i < len(states); i++ {
+		dx, dy := float64(states[i].X-states[i-1].X), float64(states[i].Y-states[i-1].Y)
+		lengths[i] = lengths[i-1] + float32(math.Hypot(dx, dy))
+	}
+	total := lengths[len(lengths)-1]
+	timeAt := func(i int) float32 {
+		if total == 0 {
+			return t1 + (t2-t1)*float32(i)/float32(len(states)-1)
+		}
+		return t1 + (t2-t1)*lengths[i]/total
+	}
+
+	for i := 1; i < len(states); i++ {
+		for _, o := range c.Moving {
+			if o.SegmentCollisionAt(states[i-1], states[i], timeAt(i-1), timeAt(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // Draw the configuration space
 func (c *Config) Draw(screen *gg.Context) {
 	for _, o := range c.Obstacles {
 		o.Draw(screen)
 	}
+	for _, o := range c.Moving {
+		o.Draw(screen)
+	}
+}
+
+// Draw the configuration space projected onto two axes, obstacles that cannot
+// be projected are only drawn in the top view
+func (c *Config) DrawProjection(screen *gg.Context, u int, v int) {
+	for _, o := range c.Obstacles {
+		if p, ok := o.(Projector); ok {
+			p.DrawProjection(screen, u, v)
+		} else if u == 0 && v == 1 {
+			o.Draw(screen)
+		}
+	}
 }
@@ -0,0 +1,37 @@
+package configspace
+
+// Benchmarks for setup.go. Benchmarks the following functions:
+// Visible
+//
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Benchmark Visible on each bundled maze with segments no longer than the
+// visibility radius, as drawn when extending the tree
+func BenchmarkVisible(b *testing.B) {
+	for _, maze := range []string{"extraeasyMaze", "easyMaze", "mediumMaze", "hardMaze"} {
+		b.Run(maze, func(b *testing.B) {
+			config := NewConfigSpace("../data/" + maze + ".txt")
+			rng := rand.New(rand.NewSource(1))
+			segments := make([][2]*Point, 1024)
+			for i := range segments {
+				from := &Point{X: rng.Float32() * config.WinWidth, Y: rng.Float32() * config.WinHeight}
+				angle := rng.Float64() * 2 * math.Pi
+				to := &Point{
+					X: from.X + config.Visibility*float32(math.Cos(angle)),
+					Y: from.Y + config.Visibility*float32(math.Sin(angle)),
+				}
+				segments[i] = [2]*Point{from, to}
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				segment := segments[i%len(segments)]
+				config.Visible(segment[0], segment[1])
+			}
+		})
+	}
+}
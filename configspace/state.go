@@ -0,0 +1,101 @@
+package configspace
+
+import (
+	"math"
+	"strconv"
+)
+
+// Limit is the range of values a coordinate of the state may take
+type Limit struct {
+	Min float32
+	Max float32
+}
+
+// CollisionChecker decides whether the robot can move between two nearby
+// states without colliding, overriding the built-in point and footprint checks
+type CollisionChecker interface {
+	MotionValid(from *Point, to *Point) bool
+}
+
+// NewPointN creates a new Point from a list of coordinates, the first two are
+// stored as X and Y and the remainder in Q
+func NewPointN(coords []float32) *Point {
+	pt := &Point{X: coords[0], Y: coords[1]}
+	if len(coords) > 2 {
+		pt.Q = append([]float32{}, coords[2:]...)
+	}
+	return pt
+}
+
+// Dimension of the point
+func (pt *Point) Dim() int {
+	return 2 + len(pt.Q)
+}
+
+// Get the i-th coordinate of the point
+func (pt *Point) Coord(i int) float32 {
+	switch i {
+	case 0:
+		return pt.X
+	case 1:
+		return pt.Y
+	}
+	return pt.Q[i-2]
+}
+
+// Set the i-th coordinate of the point
+func (pt *Point) SetCoord(i int, value float32) {
+	switch i {
+	case 0:
+		pt.X = value
+	case 1:
+		pt.Y = value
+	default:
+		pt.Q[i-2] = value
+	}
+}
+
+// Get the coordinate limits of the configuration space, planar spaces without
+// joint limits are bounded by the window
+func (c *Config) Bounds() []Limit {
+	if len(c.Limits) > 0 {
+		return c.Limits
+	}
+	return []Limit{{0, c.WinWidth}, {0, c.WinHeight}}
+}
+
+// Dimension of the configuration space
+func (c *Config) Dim() int {
+	return len(c.Bounds())
+}
+
+// Parse a list of coordinates into a point of the given dimension, missing
+// coordinates are zero
+func parseCoords(config []string, dim int) *Point {
+	coords := make([]float32, dim)
+	for i := 0; i < dim && i < len(config); i++ {
+		coord, _ := strconv.ParseFloat(config[i], 32)
+		coords[i] = float32(coord)
+	}
+	return NewPointN(coords)
+}
+
+// Parse a joint's limits
+func parseLimit(config []string) Limit {
+	min, _ := strconv.ParseFloat(config[0], 32)
+	max, _ := strconv.ParseFloat(config[1], 32)
+	return Limit{float32(min), float32(max)}
+}
+
+// Default limits of a revolute joint
+var revoluteLimit = Limit{-math.Pi, math.Pi}
+
+// Check if a point lies within the configuration space's bounds
+func (c *Config) InBounds(pt *Point) bool {
+	for i, limit := range c.Bounds() {
+		if coord := pt.Coord(i); coord < limit.Min || coord > limit.Max {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package configspace
+
+// Unit testing for state.go and arm.go. Tests the following functions:
+// NewPointN
+// Bounds
+// PlanarArm MotionValid
+//
+
+import (
+	"math"
+	"testing"
+)
+
+// Test NewPointN stores extra coordinates in Q
+func TestNewPointN(t *testing.T) {
+	pt := NewPointN([]float32{1, 2, 3, 4})
+	if pt.Dim() != 4 || pt.X != 1 || pt.Y != 2 || pt.Coord(3) != 4 {
+		t.Errorf("NewPointN failed, got %+v", *pt)
+	}
+	pt.SetCoord(2, 5)
+	if pt.Q[0] != 5 {
+		t.Error("SetCoord failed")
+	}
+}
+
+// Test the window bounds planar spaces without joint limits
+func TestBounds(t *testing.T) {
+	config := &Config{WinWidth: 10, WinHeight: 20}
+	if b := config.Bounds(); len(b) != 2 || b[0].Max != 10 || b[1].Max != 20 {
+		t.Errorf("Expected window bounds, got %v", b)
+	}
+	config.Limits = []Limit{{-1, 1}, {-1, 1}, {-1, 1}}
+	if config.Dim() != 3 {
+		t.Errorf("Expected joint limits to set dimension, got %v", config.Dim())
+	}
+}
+
+// Test an arm swinging through an obstacle is rejected
+func TestPlanarArmMotionValid(t *testing.T) {
+	wall := NewRectangleObstacle([]string{"5", "-1", "2", "1"})
+	arm := NewPlanarArm([]string{"0", "0", "4", "4"}, []Obstacle{wall})
+	straight := NewPointN([]float32{0, 0})
+	up := NewPointN([]float32{math.Pi / 2, 0})
+	down := NewPointN([]float32{-math.Pi / 2, 0})
+	if arm.MotionValid(straight, straight) {
+		t.Error("Expected straight arm to hit wall")
+	}
+	if !arm.MotionValid(up, up) {
+		t.Error("Expected raised arm to be clear")
+	}
+	if arm.MotionValid(up, down) {
+		t.Error("Expected sweep through wall to collide")
+	}
+}
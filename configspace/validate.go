@@ -0,0 +1,142 @@
+package configspace
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Number of values each keyword of a config file takes, a maximum of -1 is
+// unbounded
+var keywordFields = map[string][2]int{
+	"window":             {2, 2},
+	"depth":              {1, 1},
+	"visibility":         {1, 1},
+	"start":              {2, -1},
+	"goal":               {2, -1},
+	"joint":              {2, 2},
+	"arm":                {3, -1},
+	"robot":              {6, -1},
+	"steering":           {1, 4},
+	"rectangle":          {4, 4},
+	"speed":              {1, 1},
+	"horizon":            {1, 1},
+	"movingrectangle":    {6, 6},
+	"scheduledrectangle": {5, -1},
+	"box":                {6, 6},
+	"sphere":             {4, 4},
+}
+
+// Steering methods a config file can name
+var steeringNames = map[string]bool{
+	"straight": true, "dubins": true, "reedsshepp": true, "se2": true, "doubleintegrator": true,
+}
+
+// Validate checks a config file for problems that NewConfigSpace would
+// silently ignore, such as unknown keywords, malformed numbers and a start or
+// goal outside the space or inside an obstacle. It returns every problem
+// found, nil if the file is valid
+func Validate(configPath string) []error {
+	if _, err := os.Stat(configPath); err != nil {
+		return []error{err}
+	}
+
+	// Check each line on its own
+	var errs []error
+	seen := make(map[string]bool)
+	var solids []error // Obstacles that are invalid without a depth
+	for i, line := range ReadFile(configPath) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		if err := validateLine(fields); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", i+1, err))
+		}
+		seen[fields[0]] = true
+		if fields[0] == "box" || fields[0] == "sphere" {
+			solids = append(solids, fmt.Errorf("line %d: %s needs a window depth", i+1, fields[0]))
+		}
+	}
+
+	// Check the space as a whole
+	for _, keyword := range []string{"visibility", "start", "goal"} {
+		if !seen[keyword] {
+			errs = append(errs, fmt.Errorf("missing %s", keyword))
+		}
+	}
+	if !seen["window"] && !seen["joint"] && !seen["arm"] {
+		errs = append(errs, fmt.Errorf("missing window"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	config := NewConfigSpace(configPath)
+	if config.Visibility <= 0 {
+		errs = append(errs, fmt.Errorf("visibility must be positive, got %g", config.Visibility))
+	}
+	for _, limit := range config.Bounds() {
+		if limit.Max <= limit.Min {
+			errs = append(errs, fmt.Errorf("empty bounds [%g, %g]", limit.Min, limit.Max))
+		}
+	}
+	if config.WinDepth <= 0 {
+		errs = append(errs, solids...)
+	}
+	errs = append(errs, validateState(config, "start", config.Start)...)
+	errs = append(errs, validateState(config, "goal", config.Goal)...)
+	return errs
+}
+
+// Check a line's keyword is known and its values are numbers of the right
+// count
+func validateLine(fields []string) error {
+	keyword, values := fields[0], fields[1:]
+	count, ok := keywordFields[keyword]
+	if !ok {
+		return fmt.Errorf("unknown keyword %q", keyword)
+	}
+	if len(values) < count[0] || (count[1] >= 0 && len(values) > count[1]) {
+		if count[0] == count[1] {
+			return fmt.Errorf("%s takes %d values, got %d", keyword, count[0], len(values))
+		}
+		return fmt.Errorf("%s takes at least %d values, got %d", keyword, count[0], len(values))
+	}
+
+	// Steering is named before its parameters
+	if keyword == "steering" {
+		if !steeringNames[values[0]] {
+			return fmt.Errorf("unknown steering %q", values[0])
+		}
+		values = values[1:]
+	}
+	if keyword == "scheduledrectangle" && (len(values)-2)%3 != 0 {
+		return fmt.Errorf("scheduledrectangle waypoints take a time and position, got %d values",
+			len(values)-2)
+	}
+	for _, value := range values {
+		if _, err := strconv.ParseFloat(value, 32); err != nil {
+			return fmt.Errorf("%s value %q is not a number", keyword, value)
+		}
+	}
+	return nil
+}
+
+// Check a start or goal state lies in bounds and clear of obstacles
+func validateState(config *Config, name string, pt *Point) []error {
+	if !config.InBounds(pt) {
+		return []error{fmt.Errorf("%s lies outside the configuration space", name)}
+	}
+	inside := !config.PathVisible([]*Point{pt, pt})
+	for _, o := range config.Obstacles {
+		if r, ok := o.(*rectangleObstacle); ok && r.contains(pt) {
+			inside = true
+		}
+	}
+	if inside {
+		return []error{fmt.Errorf("%s lies inside an obstacle", name)}
+	}
+	return nil
+}
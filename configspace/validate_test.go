@@ -0,0 +1,76 @@
+package configspace
+
+// Unit testing for validate.go and generate.go. Tests the following functions:
+// Validate
+// Generate
+//
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Write a config file to a temporary directory
+func writeConfig(t *testing.T, config string) string {
+	configPath := filepath.Join(t.TempDir(), "config.txt")
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return configPath
+}
+
+// Test a valid config file has no problems
+func TestValidateValid(t *testing.T) {
+	configPath := writeConfig(t, "window,100,100\nvisibility,10\nstart,5,5\ngoal,95,95\nrectangle,40,40,20,20\n")
+	if errs := Validate(configPath); errs != nil {
+		t.Errorf("Expected no problems, got %v", errs)
+	}
+}
+
+// Test each kind of problem is reported with its line
+func TestValidateProblems(t *testing.T) {
+	tests := []struct {
+		config string
+		want   string
+	}{
+		{"window,100,100\nvisibility,10\nstart,5,5\ngoal,95,95\ncircle,1,2,3\n", "line 5: unknown keyword"},
+		{"window,100,100\nvisibility,ten\nstart,5,5\ngoal,95,95\n", "line 2: visibility value"},
+		{"window,100,100\nvisibility,10\nstart,5,5\ngoal,95,95\nrectangle,1,2\n", "line 5: rectangle takes 4"},
+		{"window,100,100\nvisibility,10\nsteering,tank\nstart,5,5\ngoal,95,95\n", "unknown steering"},
+		{"window,100,100\nvisibility,10\nstart,5,5\n", "missing goal"},
+		{"window,100,100\nvisibility,10\nstart,5,5\ngoal,150,95\n", "goal lies outside"},
+		{"window,100,100\nvisibility,10\nstart,50,50\ngoal,95,95\nrectangle,40,40,20,20\n", "start lies inside"},
+		{"window,100,100\nvisibility,10\nstart,5,5\ngoal,95,95\nbox,50,50,0,20,20,20\n", "line 5: box needs a window depth"},
+		{"window,100,100\nvisibility,10\nstart,5,5\ngoal,95,95\nsphere,50,50,0,10\n", "line 5: sphere needs a window depth"},
+	}
+	for _, test := range tests {
+		errs := Validate(writeConfig(t, test.config))
+		if len(errs) == 0 || !strings.Contains(errs[0].Error(), test.want) {
+			t.Errorf("Expected %q, got %v", test.want, errs)
+		}
+	}
+}
+
+// Test a generated config file is valid and has the requested obstacles
+func TestGenerate(t *testing.T) {
+	var config strings.Builder
+	opts := GenerateOptions{Width: 500, Height: 400, Visibility: 30, Obstacles: 25, MinSize: 10, MaxSize: 80}
+	if err := Generate(&config, opts, rand.New(rand.NewSource(1))); err != nil {
+		t.Fatal(err)
+	}
+	configPath := writeConfig(t, config.String())
+	if errs := Validate(configPath); errs != nil {
+		t.Errorf("Expected a valid config, got %v", errs)
+	}
+	space := NewConfigSpace(configPath)
+	if len(space.Obstacles) != 25 || space.WinWidth != 500 || space.WinHeight != 400 {
+		t.Errorf("Expected 25 obstacles in a 500x400 window, got %d in %gx%g",
+			len(space.Obstacles), space.WinWidth, space.WinHeight)
+	}
+	if err := Generate(&config, GenerateOptions{Width: 10, Height: 10}, rand.New(rand.NewSource(1))); err == nil {
+		t.Error("Expected an error without a visibility radius")
+	}
+}
@@ -1,16 +1,18 @@
 package deque
 
+import "sync/atomic"
+
 // CircularArray struct used internally by the unboundedDEQue
 type CircularArray[T any] struct {
-	c     int32 // Current capacity
-	tasks []*T  // Tasks array
+	c     int32               // Current capacity
+	tasks []atomic.Pointer[T] // Tasks array
 }
 
 // Create new circular array
 func NewCircularArray[T any](capacity int32) *CircularArray[T] {
 	return &CircularArray[T]{
 		c:     capacity,
-		tasks: make([]*T, capacity),
+		tasks: make([]atomic.Pointer[T], capacity),
 	}
 }
 
@@ -21,12 +23,12 @@ func (a *CircularArray[T]) Capacity() int32 {
 
 // Get item from array
 func (a *CircularArray[T]) Get(item_idx int32) *T {
-	return a.tasks[item_idx%a.c]
+	return a.tasks[item_idx%a.c].Load()
 }
 
 // Put item onto array
 func (a *CircularArray[T]) Put(item_idx int32, item *T) {
-	a.tasks[item_idx%a.c] = item
+	a.tasks[item_idx%a.c].Store(item)
 }
 
 // Resize array and copy task references
@@ -6,76 +6,141 @@ import "sync/atomic"
 type DEQue[T any] interface {
 	PushBottom(task *T)
 	IsEmpty() bool
+	Size() int
 	PopTop() *T
+	PopTopHalf(max int) []*T
 	PopBottom() *T
 }
 
 // unboundedDEQue struct
 type unboundedDEQue[T any] struct {
-	tasks  *CircularArray[T] // Circular array of tasks
-	bottom int32             // Bottom index
-	top    atomic.Int32      // Top index
+	tasks  atomic.Pointer[CircularArray[T]] // Circular array of tasks
+	bottom atomic.Int32                     // Bottom index, only changed by the owner
+	top    atomic.Uint64                    // Top index in the low half, owner pops counted in the high half
+	high   int32                            // Highest bottom since the last counted pop, owner only
+}
+
+// A counted owner pop adds popTag to top, so thieves that read the bottom
+// before the pop fail their compare and swap. The top index only grows, so a
+// steal needs no tag
+const popTag = 1 << 32
+
+// Get the index held by a top value
+func topIndex(top uint64) int32 {
+	return int32(uint32(top))
 }
 
 // Create a new unboundedDEQue
 func NewUnboundedDEQue[T any]() DEQue[T] {
-	return &unboundedDEQue[T]{
-		tasks:  NewCircularArray[T](32),
-		bottom: 0,
-		top:    atomic.Int32{},
-	}
+	d := &unboundedDEQue[T]{}
+	d.tasks.Store(NewCircularArray[T](32))
+	return d
 }
 
 // Check if deque empty
 func (d *unboundedDEQue[T]) IsEmpty() bool {
-	return d.bottom <= d.top.Load()
+	return d.bottom.Load() <= topIndex(d.top.Load())
+}
+
+// Get the number of tasks in the deque
+func (d *unboundedDEQue[T]) Size() int {
+	size := d.bottom.Load() - topIndex(d.top.Load())
+	if size < 0 {
+		return 0
+	}
+	return int(size)
 }
 
 // Push to bottom (non-concurrent)
 func (d *unboundedDEQue[T]) PushBottom(task *T) {
-	oldBottom := d.bottom
-	oldTop := d.top.Load()
-	size := oldBottom - oldTop
-	if size >= d.tasks.Capacity()-1 {
-		d.tasks = d.tasks.Resize(oldTop, oldBottom)
+	oldBottom := d.bottom.Load()
+	oldTop := topIndex(d.top.Load())
+	tasks := d.tasks.Load()
+	if oldBottom-oldTop >= tasks.Capacity()-1 {
+		tasks = tasks.Resize(oldTop, oldBottom)
+		d.tasks.Store(tasks)
+	}
+	tasks.Put(oldBottom, task)
+	d.bottom.Store(oldBottom + 1)
+	if oldBottom+1 > d.high {
+		d.high = oldBottom + 1
 	}
-	d.tasks.Put(oldBottom, task)
-	d.bottom++
 }
 
-// Pop from bottom (concurrent only with PopTop)
+// Pop from bottom (concurrent only with PopTop and PopTopHalf). Thieves take
+// at most half of the tasks they see, so a thief that read a bottom of at
+// most high cannot reach past the middle of the tasks and popping beyond it
+// needs no synchronization. Closer pops are counted in top first
 func (d *unboundedDEQue[T]) PopBottom() *T {
-	d.bottom--
-	oldTop := d.top.Load()
-	newTop := oldTop + 1
-	size := d.bottom - oldTop
-	if size < 0 {
-		d.bottom = oldTop
+	oldBottom := d.bottom.Load()
+	top := d.top.Load()
+	if oldBottom-1 < topIndex(top) {
 		return nil
 	}
-	task := d.tasks.Get(d.bottom)
-	if size > 0 {
-		return task
+	if oldBottom-1 > topIndex(top) {
+		newBottom := oldBottom - 1
+		d.bottom.Store(newBottom)
+		top = d.top.Load()
+		if oldTop := topIndex(top); newBottom > oldTop && newBottom >= oldTop+(d.high-oldTop+1)/2 {
+			return d.tasks.Load().Get(newBottom)
+		}
+		// Thieves reading top after the pop see the lowered bottom
+		top = d.top.Add(popTag)
+		d.high = newBottom
+		oldTop := topIndex(top)
+		if newBottom < oldTop {
+			d.bottom.Store(oldTop)
+			return nil
+		}
+		if newBottom > oldTop {
+			return d.tasks.Load().Get(newBottom)
+		}
+		d.bottom.Store(oldBottom)
 	}
-	// Only use compare and sway when task is last
-	if !d.top.CompareAndSwap(oldTop, newTop) {
+	// Only use compare and swap when task is last, the bottom is then one past
+	// the new top
+	task := d.tasks.Load().Get(topIndex(top))
+	if !d.top.CompareAndSwap(top, top+1) {
 		task = nil
 	}
-	d.bottom = newTop
 	return task
 }
 
 // Pop from top (concurrent)
 func (d *unboundedDEQue[T]) PopTop() *T {
-	oldTop := d.top.Load()
-	newTop := oldTop + 1
-	size := d.bottom - oldTop
-	if size <= 0 {
+	top := d.top.Load()
+	oldTop := topIndex(top)
+	if d.bottom.Load() <= oldTop {
 		return nil
 	}
-	task := d.tasks.Get(oldTop)
-	if d.top.CompareAndSwap(oldTop, newTop) {
+	task := d.tasks.Load().Get(oldTop)
+	if d.top.CompareAndSwap(top, top+1) {
 		return task
 	}
 	return nil
 }
+
+// Pop half of the tasks from the top, rounded up and at most max, in one
+// atomic step (concurrent). Returns nil if the deque is empty or another
+// thread changed it first
+func (d *unboundedDEQue[T]) PopTopHalf(max int) []*T {
+	top := d.top.Load()
+	oldTop := topIndex(top)
+	size := d.bottom.Load() - oldTop
+	if size <= 0 || max <= 0 {
+		return nil
+	}
+	n := (size + 1) / 2
+	if n > int32(max) {
+		n = int32(max)
+	}
+	tasks := d.tasks.Load()
+	stolen := make([]*T, n)
+	for i := range stolen {
+		stolen[i] = tasks.Get(oldTop + int32(i))
+	}
+	if d.top.CompareAndSwap(top, top+uint64(n)) {
+		return stolen
+	}
+	return nil
+}
@@ -53,6 +53,41 @@ func TestUnboundedDEQue_PopBottom(t *testing.T) {
 	}
 }
 
+func TestUnboundedDEQue_Size(t *testing.T) {
+	deque := NewUnboundedDEQue[int]()
+	values := make([]int, 100)
+	for i := range values {
+		deque.PushBottom(&values[i])
+	}
+	deque.PopTop()
+	deque.PopBottom()
+	if deque.Size() != 98 {
+		t.Errorf("Expected 98, got %v", deque.Size())
+	}
+}
+
+func TestUnboundedDEQue_PopTopHalf(t *testing.T) {
+	deque := NewUnboundedDEQue[int]()
+	values := make([]int, 9)
+	for i := range values {
+		values[i] = i
+		deque.PushBottom(&values[i])
+	}
+	// Half rounded up, oldest first
+	stolen := deque.PopTopHalf(100)
+	if len(stolen) != 5 || *stolen[0] != 0 || *stolen[4] != 4 || deque.Size() != 4 {
+		t.Errorf("Expected tasks 0 to 4 stolen, got %v leaving %v", len(stolen), deque.Size())
+	}
+	if stolen = deque.PopTopHalf(1); len(stolen) != 1 || *stolen[0] != 5 {
+		t.Errorf("Expected task 5 stolen, got %v", len(stolen))
+	}
+	deque.PopTopHalf(100)
+	deque.PopTopHalf(100)
+	if !deque.IsEmpty() || deque.PopTopHalf(100) != nil {
+		t.Errorf("Expected an empty deque")
+	}
+}
+
 func ParallelDequeTest(t *testing.T, deque DEQue[int64], numThreads int, numOps int) {
 
 	var wg sync.WaitGroup
@@ -156,7 +191,150 @@ func ParallelContentionTest(t *testing.T, deque DEQue[int64], numThreads int, nu
 	}
 }
 
+func ParallelStealHalfTest(t *testing.T, deque DEQue[int64], numThreads int, numOps int) {
+
+	var wg sync.WaitGroup
+	var sum int64
+	var parallel_sum atomic.Int64
+	var taken atomic.Int64
+
+	wg.Add(numThreads)
+	for i := 0; i < numThreads; i++ {
+		go func() {
+			defer wg.Done()
+			for taken.Load() < int64(numOps) {
+				for _, value := range deque.PopTopHalf(8) {
+					parallel_sum.Add(*value)
+					taken.Add(1)
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numOps; i++ {
+			i64 := int64(i)
+			sum += i64
+			deque.PushBottom(&i64)
+			if i%3 == 0 {
+				if value := deque.PopBottom(); value != nil {
+					parallel_sum.Add(*value)
+					taken.Add(1)
+					deque.PushBottom(value)
+					parallel_sum.Add(-*value)
+					taken.Add(-1)
+				}
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if parallel_sum.Load() != sum {
+		t.Errorf("Expected %v, got %v", sum, parallel_sum.Load())
+	}
+}
+
+func TestUnboundedDEQue_ParallelStealHalf(t *testing.T) {
+	deque := NewUnboundedDEQue[int64]()
+	ParallelStealHalfTest(t, deque, 8, 1000000)
+}
+
+// Test the owner draining its deque while thieves steal half of it, so pops
+// reach the tasks thieves may be stealing
+func TestUnboundedDEQue_ParallelDrain(t *testing.T) {
+	deque := NewUnboundedDEQue[int64]()
+	var wg sync.WaitGroup
+	var sum int64
+	var parallel_sum atomic.Int64
+	var done atomic.Bool
+
+	wg.Add(4)
+	for i := 0; i < 4; i++ {
+		go func() {
+			defer wg.Done()
+			for !done.Load() {
+				for _, value := range deque.PopTopHalf(8) {
+					parallel_sum.Add(*value)
+				}
+			}
+		}()
+	}
+
+	for round := 0; round < 20000; round++ {
+		for i := 0; i < 64; i++ {
+			i64 := int64(round*64 + i)
+			sum += i64
+			deque.PushBottom(&i64)
+		}
+		for value := deque.PopBottom(); value != nil; value = deque.PopBottom() {
+			parallel_sum.Add(*value)
+		}
+	}
+	done.Store(true)
+	wg.Wait()
+
+	if parallel_sum.Load() != sum {
+		t.Errorf("Expected %v, got %v", sum, parallel_sum.Load())
+	}
+}
+
 func TestUnboundedDEQue_ParallelContention(t *testing.T) {
 	deque := NewUnboundedDEQue[int64]()
 	ParallelContentionTest(t, deque, 50, 10000000)
 }
+
+// Benchmark a worker pushing and popping its own tasks
+func BenchmarkUnboundedDEQue_PushPopBottom(b *testing.B) {
+	deque := NewUnboundedDEQue[int]()
+	value := 1
+	for i := 0; i < b.N; i++ {
+		deque.PushBottom(&value)
+		deque.PopBottom()
+	}
+}
+
+// Benchmark a thief stealing from an uncontended deque
+func BenchmarkUnboundedDEQue_PopTop(b *testing.B) {
+	deque := NewUnboundedDEQue[int]()
+	value := 1
+	for i := 0; i < b.N; i++ {
+		deque.PushBottom(&value)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		deque.PopTop()
+	}
+}
+
+// Benchmark thieves stealing while the owner pushes and pops
+func BenchmarkUnboundedDEQue_Steal(b *testing.B) {
+	deque := NewUnboundedDEQue[int]()
+	value := 1
+	done := make(chan struct{})
+	var owner sync.WaitGroup
+	owner.Add(1)
+	go func() {
+		defer owner.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				deque.PushBottom(&value)
+				deque.PushBottom(&value)
+				deque.PopBottom()
+				deque.PopBottom()
+			}
+		}
+	}()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			deque.PopTop()
+		}
+	})
+	close(done)
+	owner.Wait()
+}
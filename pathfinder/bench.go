@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"proj3-redesigned/benchmark"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Mazes benchmarked by default, each planned with every sample size
+const defaultMazes = "data/extraeasyMaze.txt,data/easyMaze.txt,data/mediumMaze.txt,data/hardMaze.txt"
+
+// Measure the speedup of the parallel strategies over the sequential baseline
+// for every combination of maze, sample size and thread count
+func runBench(args []string) error {
+	fs := newFlagSet("bench", "Measure the speedup of ws and bsp over the sequential baseline for every\n"+
+		"combination of config file, sample size and thread count. Progress is printed\n"+
+		"to stderr and the results to stdout.")
+	configs := fs.String("config", defaultMazes, "comma-separated configuration space `files`")
+	samples := fs.String("samples", "4000", "comma-separated sample `sizes`")
+	strategies := fs.String("strategies", "ws,bsp", "comma-separated parallel `strategies`, empty for the baseline only")
+	threads := fs.String("threads", "2,4,6,8,12", "comma-separated thread `counts` of the parallel strategies")
+	warmup := fs.Int("warmup", 1, "untimed runs before each measurement")
+	reps := fs.Int("reps", 5, "timed runs of each measurement")
+	seed := fs.Int64("seed", 0, "seed the sampler so every run draws the same samples, random when 0")
+	csvFile := fs.String("csv", "", "write the results to a CSV `file`")
+	chartFile := fs.String("chart", "", "write a speedup chart to a PNG `file`")
+	var rf reportFlag
+	rf.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	// Build the matrix, checking every flag before the first run
+	sizes, err := intList("samples", *samples)
+	if err != nil {
+		return err
+	}
+	threadCounts, err := intList("threads", *threads)
+	if err != nil {
+		return err
+	}
+	matrix := benchmark.Matrix{
+		Strategies: stringList(*strategies),
+		Threads:    threadCounts,
+		Warmup:     *warmup,
+		Reps:       *reps,
+		Seed:       *seed,
+		Progress: func(row benchmark.Row) {
+			fmt.Fprintf(os.Stderr, "%s %d %s %d: %.2fs\n", row.Config, row.Samples, row.Strategy,
+				row.Threads, row.Median.Seconds())
+		},
+	}
+	for _, config := range stringList(*configs) {
+		if err := checkInput("config", config); err != nil {
+			return err
+		}
+		for _, size := range sizes {
+			matrix.Cases = append(matrix.Cases, benchmark.Case{Config: config, Samples: size})
+		}
+	}
+	if *csvFile != "" {
+		if err := checkExt("csv", *csvFile, ".csv"); err != nil {
+			return err
+		}
+	}
+	if *chartFile != "" {
+		if err := checkExt("chart", *chartFile, ".png"); err != nil {
+			return err
+		}
+	}
+	if err := rf.validate(); err != nil {
+		return err
+	}
+
+	rows, err := benchmark.Run(context.Background(), matrix)
+	if err != nil {
+		return err
+	}
+
+	if *csvFile != "" {
+		if err := writeFile(*csvFile, func(f *os.File) error { return benchmark.WriteCSV(f, rows) }); err != nil {
+			return fmt.Errorf("csv: %w", err)
+		}
+	}
+	if *chartFile != "" {
+		if err := writeFile(*chartFile, func(f *os.File) error { return benchmark.WriteChart(f, rows) }); err != nil {
+			return fmt.Errorf("chart: %w", err)
+		}
+	}
+
+	if rf.json() {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(benchRows(rows))
+	}
+	table := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "config\tsamples\tstrategy\tthreads\tmedian\tstddev\tspeedup\tefficiency")
+	for _, row := range rows {
+		fmt.Fprintf(table, "%s\t%d\t%s\t%d\t%.3fs\t%.3fs\t%.2f\t%.2f\n", row.Config, row.Samples,
+			row.Strategy, row.Threads, row.Median.Seconds(), row.Stddev.Seconds(), row.Speedup,
+			row.Efficiency)
+	}
+	return table.Flush()
+}
+
+// benchRow is a benchmark row in a JSON report, times are in seconds
+type benchRow struct {
+	Config     string    `json:"config"`
+	Samples    int       `json:"samples"`
+	Strategy   string    `json:"strategy"`
+	Threads    int       `json:"threads"`
+	Times      []float64 `json:"times_s"`
+	Median     float64   `json:"median_s"`
+	Stddev     float64   `json:"stddev_s"`
+	Speedup    float64   `json:"speedup"`
+	Efficiency float64   `json:"efficiency"`
+}
+
+// Convert benchmark rows for a JSON report
+func benchRows(rows []benchmark.Row) []benchRow {
+	out := make([]benchRow, len(rows))
+	for i, row := range rows {
+		times := make([]float64, len(row.Times))
+		for j, t := range row.Times {
+			times[j] = t.Seconds()
+		}
+		out[i] = benchRow{row.Config, row.Samples, row.Strategy, row.Threads, times,
+			row.Median.Seconds(), row.Stddev.Seconds(), row.Speedup, row.Efficiency}
+	}
+	return out
+}
+
+// Split a comma-separated list, dropping empty entries
+func stringList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// Parse a comma-separated list of positive integers
+func intList(name string, value string) ([]int, error) {
+	var values []int
+	for _, v := range stringList(value) {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("-%s must be positive integers, got %q", name, v)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+// Create a file and write to it
+func writeFile(path string, write func(f *os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return write(f)
+}
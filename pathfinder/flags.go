@@ -0,0 +1,264 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"proj3-redesigned/planner"
+	"proj3-redesigned/robotpath"
+	"proj3-redesigned/sampling"
+	"proj3-redesigned/trajectory"
+	"strings"
+	"time"
+)
+
+// Create a flag set for a command, errors are returned rather than exiting
+// so main decides the exit status
+func newFlagSet(name string, summary string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: pathfinder %s [flags]\n\n%s\n\nFlags:\n", name, summary)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// Parse a command's flags, positional arguments are not accepted. The flags
+// are only listed when asked for, other errors are left to the caller
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	fs.SetOutput(io.Discard)
+	err := fs.Parse(args)
+	fs.SetOutput(nil)
+	if errors.Is(err, flag.ErrHelp) {
+		fs.Usage()
+	}
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q, every option is a named flag", fs.Arg(0))
+	}
+	return nil
+}
+
+// Check that a required flag was given
+func required(name string, value string) error {
+	if value == "" {
+		return fmt.Errorf("-%s is required", name)
+	}
+	return nil
+}
+
+// Check that an input file exists
+func checkInput(name string, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("-%s: %w", name, err)
+	}
+	return nil
+}
+
+// Check that an output file has one of the given extensions
+func checkExt(name string, path string, exts ...string) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range exts {
+		if ext == e {
+			return nil
+		}
+	}
+	return fmt.Errorf("-%s: unknown format %q, expected %s", name, filepath.Ext(path),
+		strings.Join(exts, ", "))
+}
+
+// planFlags select the configuration space and how it is planned
+type planFlags struct {
+	config    string
+	load      string
+	samples   int
+	strategy  string
+	threads   int
+	batch     int
+	seed      int64
+	sampler   string
+	goalBias  float64
+	neighbors string
+	k         int
+	budget    time.Duration
+}
+
+// Register the planning flags
+func (f *planFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.config, "config", "", "configuration space `file` (required)")
+	fs.StringVar(&f.load, "load", "", "resume from a checkpoint `file`, adding -samples to its tree")
+	fs.IntVar(&f.samples, "samples", 0, "`number` of samples drawn, unlimited under -budget when 0")
+	fs.StringVar(&f.strategy, "strategy", "sequential", "sequential, ws (work stealing) or bsp (bulk synchronous parallel)")
+	fs.IntVar(&f.threads, "threads", 1, "`number` of goroutines, at least 2 for ws and bsp")
+	fs.IntVar(&f.batch, "batch", 1, "`samples` each bsp thread draws per superstep")
+	fs.Int64Var(&f.seed, "seed", 0, "seed the sampler for repeatable sequential and bsp runs, random when 0")
+	fs.StringVar(&f.sampler, "sampler", "uniform", "uniform or goalbias")
+	fs.Float64Var(&f.goalBias, "goalbias", 0.05, "`probability` the goalbias sampler draws the goal")
+	fs.StringVar(&f.neighbors, "neighbors", "fixed", "fixed or log neighbors considered when rewiring")
+	fs.IntVar(&f.k, "k", 10, "`number` of neighbors considered by the fixed policy")
+	fs.DurationVar(&f.budget, "budget", 0, "stop drawing samples after this `duration`, e.g. 30s")
+}
+
+// Check the planning flags before any work is done
+func (f *planFlags) validate() error {
+	if err := required("config", f.config); err != nil {
+		return err
+	}
+	if err := checkInput("config", f.config); err != nil {
+		return err
+	}
+	if f.load != "" {
+		if err := checkInput("load", f.load); err != nil {
+			return err
+		}
+	}
+	parallel := f.strategy == "ws" || f.strategy == "bsp"
+	switch {
+	case f.samples < 0:
+		return fmt.Errorf("-samples must not be negative, got %d", f.samples)
+	case f.budget < 0:
+		return fmt.Errorf("-budget must not be negative, got %v", f.budget)
+	case f.samples == 0 && f.budget == 0:
+		return errors.New("-samples or -budget is required")
+	case f.strategy != "sequential" && !parallel:
+		return fmt.Errorf("-strategy must be sequential, ws or bsp, got %q", f.strategy)
+	case f.threads < 1:
+		return fmt.Errorf("-threads must be positive, got %d", f.threads)
+	case parallel && f.threads < 2:
+		return fmt.Errorf("-strategy %s needs -threads of at least 2", f.strategy)
+	case !parallel && f.threads > 1:
+		return fmt.Errorf("-threads %d needs -strategy ws or bsp", f.threads)
+	case f.batch < 1:
+		return fmt.Errorf("-batch must be positive, got %d", f.batch)
+	case f.strategy != "bsp" && f.batch > 1:
+		return fmt.Errorf("-batch %d needs -strategy bsp", f.batch)
+	case f.sampler != "uniform" && f.sampler != "goalbias":
+		return fmt.Errorf("-sampler must be uniform or goalbias, got %q", f.sampler)
+	case f.goalBias < 0 || f.goalBias > 1:
+		return fmt.Errorf("-goalbias must be between 0 and 1, got %g", f.goalBias)
+	case f.neighbors != "fixed" && f.neighbors != "log":
+		return fmt.Errorf("-neighbors must be fixed or log, got %q", f.neighbors)
+	case f.k < 1:
+		return fmt.Errorf("-k must be positive, got %d", f.k)
+	}
+	return nil
+}
+
+// Read the configuration space, resuming a saved tree when a checkpoint is
+// given
+func (f *planFlags) path() (*robotpath.Path, error) {
+	return loadPath(f.config, f.load)
+}
+
+// Get the planner options for a path
+func (f *planFlags) options(path *robotpath.Path) planner.Options {
+	opts := planner.Options{
+		Samples:    f.samples,
+		Strategy:   f.strategy,
+		Threads:    f.threads,
+		Batch:      f.batch,
+		Seed:       f.seed,
+		Neighbors:  robotpath.FixedNeighbors(f.k),
+		TimeBudget: f.budget,
+	}
+	if f.neighbors == "log" {
+		opts.Neighbors = robotpath.LogNeighbors()
+	}
+	if f.sampler == "goalbias" {
+		opts.Sampler = sampling.NewGoalBiased(f.seed, path.Config.Goal, float32(f.goalBias))
+	}
+	return opts
+}
+
+// styleFlags select how images are drawn
+type styleFlags struct {
+	scale      float64
+	lineWidth  float64
+	costColors bool
+	heatmap    bool
+	rejected   bool
+	legend     bool
+}
+
+// Register the style flags
+func (f *styleFlags) register(fs *flag.FlagSet) {
+	fs.Float64Var(&f.scale, "scale", 1, "image size relative to the window")
+	fs.Float64Var(&f.lineWidth, "linewidth", 5, "width of tree edges in window units")
+	fs.BoolVar(&f.costColors, "costcolors", false, "color tree edges by cost-to-come")
+	fs.BoolVar(&f.heatmap, "heatmap", false, "overlay the density of drawn samples")
+	fs.BoolVar(&f.rejected, "rejected", false, "mark samples rejected by collision checks")
+	fs.BoolVar(&f.legend, "legend", false, "show goal distance, samples and runtime")
+}
+
+// Check the style flags
+func (f *styleFlags) validate() error {
+	if f.scale <= 0 {
+		return fmt.Errorf("-scale must be positive, got %g", f.scale)
+	}
+	if f.lineWidth <= 0 {
+		return fmt.Errorf("-linewidth must be positive, got %g", f.lineWidth)
+	}
+	return nil
+}
+
+// Get the render options
+func (f *styleFlags) options() robotpath.RenderOptions {
+	return robotpath.RenderOptions{
+		Scale:      f.scale,
+		LineWidth:  f.lineWidth,
+		CostColors: f.costColors,
+		Heatmap:    f.heatmap,
+		Rejected:   f.rejected,
+		Legend:     f.legend,
+	}
+}
+
+// trajFlags select how the solved path is time-parameterized
+type trajFlags struct {
+	out   string
+	vMax  float64
+	aMax  float64
+	blend float64
+	dt    float64
+}
+
+// Register the trajectory flags
+func (f *trajFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.out, "traj", "", "write the solved path as a trajectory `file` (.csv or .json)")
+	fs.Float64Var(&f.vMax, "vmax", 100, "maximum trajectory speed")
+	fs.Float64Var(&f.aMax, "amax", 50, "maximum trajectory acceleration")
+	fs.Float64Var(&f.blend, "blend", 0, "corner blend tolerance, 0 stops at each waypoint")
+	fs.Float64Var(&f.dt, "dt", 0.1, "trajectory sample period in seconds")
+}
+
+// Check the trajectory flags
+func (f *trajFlags) validate() error {
+	if f.out == "" {
+		return nil
+	}
+	switch {
+	case f.vMax <= 0:
+		return fmt.Errorf("-vmax must be positive, got %g", f.vMax)
+	case f.aMax <= 0:
+		return fmt.Errorf("-amax must be positive, got %g", f.aMax)
+	case f.blend < 0:
+		return fmt.Errorf("-blend must not be negative, got %g", f.blend)
+	case f.dt <= 0:
+		return fmt.Errorf("-dt must be positive, got %g", f.dt)
+	}
+	return checkExt("traj", f.out, ".csv", ".json")
+}
+
+// Get the trajectory limits
+func (f *trajFlags) limits() trajectory.Limits {
+	return trajectory.Limits{
+		MaxVel: float32(f.vMax),
+		MaxAcc: float32(f.aMax),
+		Blend:  float32(f.blend),
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+// Unit testing for flags.go. Tests the following functions:
+// parseFlags
+// planFlags.validate
+// checkExt
+//
+
+import (
+	"strings"
+	"testing"
+)
+
+// Parse planning flags from a command line
+func parsePlanFlags(t *testing.T, args ...string) (*planFlags, error) {
+	fs := newFlagSet("test", "")
+	var pf planFlags
+	pf.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	return &pf, pf.validate()
+}
+
+// Test valid planning flags are accepted
+func TestPlanFlagsValid(t *testing.T) {
+	valid := [][]string{
+		{"-config", "../data/easyMaze.txt", "-samples", "100"},
+		{"-config", "../data/easyMaze.txt", "-budget", "1s"},
+		{"-config", "../data/easyMaze.txt", "-samples", "100", "-strategy", "bsp", "-threads", "4"},
+		{"-config", "../data/easyMaze.txt", "-samples", "100", "-strategy", "bsp", "-threads", "4", "-batch", "8"},
+	}
+	for _, args := range valid {
+		if _, err := parsePlanFlags(t, args...); err != nil {
+			t.Errorf("Expected %v to be valid, got %v", args, err)
+		}
+	}
+}
+
+// Test bad planning flags are reported by name
+func TestPlanFlagsInvalid(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-samples", "100"}, "-config is required"},
+		{[]string{"-config", "missing.txt", "-samples", "100"}, "-config: stat"},
+		{[]string{"-config", "../data/easyMaze.txt"}, "-samples or -budget"},
+		{[]string{"-config", "../data/easyMaze.txt", "-samples", "x"}, "invalid value"},
+		{[]string{"-config", "../data/easyMaze.txt", "-samples", "100", "-threads", "4"}, "needs -strategy"},
+		{[]string{"-config", "../data/easyMaze.txt", "-samples", "100", "-strategy", "ws"}, "at least 2"},
+		{[]string{"-config", "../data/easyMaze.txt", "-samples", "100", "-strategy", "dfs"}, "-strategy must"},
+		{[]string{"-config", "../data/easyMaze.txt", "-samples", "100", "-k", "0"}, "-k must"},
+		{[]string{"-config", "../data/easyMaze.txt", "-samples", "100", "-batch", "4"}, "needs -strategy bsp"},
+		{[]string{"-config", "../data/easyMaze.txt", "100"}, "unexpected argument"},
+	}
+	for _, test := range tests {
+		_, err := parsePlanFlags(t, test.args...)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("Expected %q for %v, got %v", test.want, test.args, err)
+		}
+	}
+}
+
+// Test output extensions are matched without case
+func TestCheckExt(t *testing.T) {
+	if err := checkExt("o", "maze.PNG", outputExts...); err != nil {
+		t.Errorf("Expected .PNG to be accepted, got %v", err)
+	}
+	if err := checkExt("o", "maze.bmp", outputExts...); err == nil {
+		t.Error("Expected .bmp to be rejected")
+	}
+}
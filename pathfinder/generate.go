@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os"
+	"proj3-redesigned/configspace"
+	"time"
+)
+
+// Write a configuration space of randomly placed rectangles
+func runGenerate(args []string) error {
+	fs := newFlagSet("generate", "Write a configuration space of randomly placed rectangles, with the\n"+
+		"start and goal in opposite corners.")
+	outFile := fs.String("o", "", "output `file` (required)")
+	width := fs.Float64("width", 1000, "window width")
+	height := fs.Float64("height", 1000, "window height")
+	visibility := fs.Float64("visibility", 60, "visibility radius")
+	obstacles := fs.Int("obstacles", 20, "`number` of rectangles")
+	minSize := fs.Float64("minsize", 20, "smallest side of a rectangle")
+	maxSize := fs.Float64("maxsize", 200, "largest side of a rectangle")
+	seed := fs.Int64("seed", 0, "seed for repeatable spaces, random when 0")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := required("o", *outFile); err != nil {
+		return err
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	opts := configspace.GenerateOptions{
+		Width:      float32(*width),
+		Height:     float32(*height),
+		Visibility: float32(*visibility),
+		Obstacles:  *obstacles,
+		MinSize:    float32(*minSize),
+		MaxSize:    float32(*maxSize),
+	}
+
+	// Generate before creating the file so bad options leave nothing behind
+	var config bytes.Buffer
+	if err := configspace.Generate(&config, opts, rand.New(rand.NewSource(*seed))); err != nil {
+		return err
+	}
+	if err := os.WriteFile(*outFile, config.Bytes(), 0644); err != nil {
+		return err
+	}
+	fmt.Println("Configuration space created.")
+	return nil
+}
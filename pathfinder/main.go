@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Usage statement
+const usage = "\nUsage:	go run proj3-redesigned/pathfinder <command> [flags]\n\n" +
+	"Commands:\n" +
+	"- plan:		plan a path and write images, GIFs, trajectories or checkpoints\n" +
+	"- bench:	measure the speedup of ws and bsp over the sequential baseline\n" +
+	"- render:	render a tree saved by plan -save without planning\n" +
+	"- validate:	check a configuration space file for problems\n" +
+	"- generate:	write a configuration space of random rectangles\n\n" +
+	"Run 'pathfinder <command> -h' for the flags of a command.\n\n" +
+	"Examples:\n" +
+	"- Sequential:	go run proj3-redesigned/pathfinder plan -samples 1000 -config data/easyMaze.txt\n" +
+	"- Parallel:	go run proj3-redesigned/pathfinder plan -samples 1000 -config data/easyMaze.txt -strategy ws -threads 4\n" +
+	"- Speedup:	go run proj3-redesigned/pathfinder bench -csv benchmark/speedup.csv -chart benchmark/speedup.png\n" +
+	"- Image:	go run proj3-redesigned/pathfinder plan -samples 1000 -config data/easyMaze.txt -o maze.jpg\n" +
+	"- Styled:	go run proj3-redesigned/pathfinder plan -samples 1000 -config data/easyMaze.txt -o maze.png -scale 0.25 -costcolors -legend\n" +
+	"- Animation:	go run proj3-redesigned/pathfinder plan -samples 1000 -config data/easyMaze.txt -strategy bsp -threads 4 -gif growth.gif\n" +
+	"- Trajectory:	go run proj3-redesigned/pathfinder plan -samples 1000 -config data/easyMaze.txt -traj out.csv\n" +
+	"- Viewer:	go run -tags ebiten proj3-redesigned/pathfinder plan -samples 10000 -config data/easyMaze.txt -strategy ws -threads 4 -view\n" +
+	"- Resume:	go run proj3-redesigned/pathfinder plan -samples 1000 -config data/easyMaze.txt -load tree.json -save tree.json\n" +
+	"- Render:	go run proj3-redesigned/pathfinder render -config data/easyMaze.txt -load tree.json -o tree.svg\n" +
+	"- Validate:	go run proj3-redesigned/pathfinder validate -config data/easyMaze.txt\n" +
+	"- Generate:	go run proj3-redesigned/pathfinder generate -obstacles 40 -seed 1 -o random.txt\n"
+
+// Commands by name
+var commands = map[string]func(args []string) error{
+	"plan":     runPlan,
+	"bench":    runBench,
+	"render":   runRender,
+	"validate": runValidate,
+	"generate": runGenerate,
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "-help" || os.Args[1] == "help" {
+		fmt.Print(usage)
+		return
+	}
+	name := os.Args[1]
+	run, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "pathfinder: unknown command %q\n", name)
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err := run(os.Args[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "pathfinder %s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
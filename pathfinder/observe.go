@@ -0,0 +1,17 @@
+package main
+
+// observer is notified of the number of samples drawn at points where no
+// updates are running, so it can safely render the tree
+type observer interface {
+	Observe(samples int)
+}
+
+// observers notifies each of a list of observers
+type observers []observer
+
+// Notify every observer
+func (obs observers) Observe(samples int) {
+	for _, o := range obs {
+		o.Observe(samples)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"proj3-redesigned/recorder"
+	"proj3-redesigned/robotpath"
+	"proj3-redesigned/trajectory"
+	"strings"
+)
+
+// Formats the tree can be written in, chosen by extension
+var outputExts = []string{".jpg", ".jpeg", ".png", ".svg", ".geojson", ".csv"}
+
+// Check if a path plans in a 3D workspace
+func is3D(output *robotpath.Path) bool {
+	return output.Config.WinDepth > 0 && output.Config.Checker == nil
+}
+
+// Write the planned tree, the format is chosen by extension and images are
+// rendered with the given options
+func writeOutput(output *robotpath.Path, outPath string, opts robotpath.RenderOptions) error {
+	if err := checkExt("o", outPath, outputExts...); err != nil {
+		return err
+	}
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(outPath)) {
+	case ".svg":
+		return output.WriteSVG(f)
+	case ".geojson":
+		return output.WriteGeoJSON(f)
+	case ".csv":
+		return output.WriteCSV(f)
+	case ".png":
+		return png.Encode(f, output.Render(opts))
+	}
+	return jpeg.Encode(f, output.Render(opts), &jpeg.Options{Quality: 100})
+}
+
+// Write a 3D workspace's tree as a PLY model
+func writeModel(output *robotpath.Path, modelPath string) error {
+	if !is3D(output) {
+		return fmt.Errorf("-model needs a 3D workspace")
+	}
+	f, err := os.Create(modelPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return output.WritePLY(f)
+}
+
+// Write the recorded frames as an animated GIF
+func writeGIF(rec *recorder.Recorder, gifPath string) error {
+	f, err := os.Create(gifPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return rec.WriteGIF(f)
+}
+
+// Create a new path from the input file, or load it from a checkpoint
+func loadPath(inputPath string, checkpointPath string) (*robotpath.Path, error) {
+	if checkpointPath == "" {
+		return robotpath.NewPath(inputPath), nil
+	}
+	f, err := os.Open(checkpointPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return robotpath.Load(f, inputPath)
+}
+
+// Write the path's tree to a checkpoint
+func savePath(output *robotpath.Path, checkpointPath string) error {
+	f, err := os.Create(checkpointPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return output.Save(f)
+}
+
+// Write the solved path as a trajectory, the format is chosen by extension
+func writeTrajectory(output *robotpath.Path, outPath string, limits trajectory.Limits,
+	dt float32,
+) error {
+	waypoints := output.Waypoints()
+	if waypoints == nil {
+		return fmt.Errorf("no path to goal found")
+	}
+	traj, err := trajectory.New(waypoints, limits)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	states := traj.Sample(dt)
+	switch strings.ToLower(filepath.Ext(outPath)) {
+	case ".csv":
+		return trajectory.WriteCSV(f, states)
+	case ".json":
+		return trajectory.WriteJSON(f, states)
+	}
+	return fmt.Errorf("unknown trajectory format %q", filepath.Ext(outPath))
+}
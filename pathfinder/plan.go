@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"proj3-redesigned/planner"
+	"proj3-redesigned/recorder"
+	"proj3-redesigned/robotpath"
+	"proj3-redesigned/viewer"
+	"time"
+)
+
+// Plan a path and write the requested outputs
+func runPlan(args []string) error {
+	fs := newFlagSet("plan", "Plan a path through a configuration space and write the results.")
+	var pf planFlags
+	var sf styleFlags
+	var tf trajFlags
+	var rf reportFlag
+	pf.register(fs)
+	sf.register(fs)
+	tf.register(fs)
+	rf.register(fs)
+	outFile := fs.String("o", "", "write the tree to `file` (.jpg, .png, .svg, .geojson or .csv)")
+	modelFile := fs.String("model", "", "write a 3D workspace's tree as a PLY model `file`")
+	gifFile := fs.String("gif", "", "record the tree's growth as an animated GIF `file`")
+	every := fs.Int("every", 0, "`samples` between GIF frames and viewer snapshots, BSP rounds up\n"+
+		"to whole supersteps (default samples/50)")
+	saveFile := fs.String("save", "", "write the tree to a checkpoint `file` after the run")
+	view := fs.Bool("view", false, "show the tree growing in a window (build with -tags ebiten)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	// Check every flag before planning, so a typo does not cost a long run
+	if err := pf.validate(); err != nil {
+		return err
+	}
+	if err := sf.validate(); err != nil {
+		return err
+	}
+	if err := tf.validate(); err != nil {
+		return err
+	}
+	if err := rf.validate(); err != nil {
+		return err
+	}
+	if *outFile != "" {
+		if err := checkExt("o", *outFile, outputExts...); err != nil {
+			return err
+		}
+	}
+	if *gifFile != "" {
+		if err := checkExt("gif", *gifFile, ".gif"); err != nil {
+			return err
+		}
+	}
+	if *every < 0 {
+		return fmt.Errorf("-every must not be negative, got %d", *every)
+	}
+
+	// Progress is only printed in text reports
+	say := func(a ...any) {
+		if !rf.json() {
+			fmt.Println(a...)
+		}
+	}
+
+	setupStart := time.Now()
+	path, err := pf.path()
+	if err != nil {
+		return fmt.Errorf("checkpoint: %w", err)
+	}
+	if *modelFile != "" && !is3D(path) {
+		return fmt.Errorf("-model needs a 3D workspace")
+	}
+	planOpts := pf.options(path)
+
+	// Record the tree's growth and feed the viewer, by default in 50 steps or
+	// every 100 samples when only a time budget is given
+	interval := *every
+	if interval == 0 {
+		interval = pf.samples / 50
+	}
+	if interval == 0 {
+		interval = 100
+	}
+	var obs observers
+	var rec *recorder.Recorder
+	if *gifFile != "" {
+		rec = recorder.New(path, interval, 0)
+		obs = append(obs, rec)
+	}
+	var feed *viewer.Feed
+	if *view {
+		feed = viewer.NewFeed(path, interval, robotpath.RenderOptions{
+			LineWidth:  sf.lineWidth,
+			CostColors: sf.costColors,
+			Heatmap:    sf.heatmap,
+			Rejected:   sf.rejected,
+		})
+		obs = append(obs, feed)
+	}
+	if len(obs) > 0 {
+		planOpts.Interval = interval
+		planOpts.Observer = obs.Observe
+	}
+
+	setup := time.Since(setupStart)
+
+	var result planner.Result
+	run := func() error {
+		var err error
+		result, err = planner.Continue(context.Background(), path, planOpts)
+		return err
+	}
+	if feed != nil {
+		// Plan in the background while the window shows the tree's growth
+		done := make(chan error, 1)
+		go func() {
+			err := run()
+			feed.Close(result.Samples)
+			done <- err
+		}()
+		if err := viewer.Run(viewer.New(feed.C), "pathfinder "+pf.config, 1024, 1024); err != nil {
+			return fmt.Errorf("viewer: %w", err)
+		}
+		err = <-done
+	} else {
+		err = run()
+	}
+	if err != nil {
+		return fmt.Errorf("planner: %w", err)
+	}
+	output := result.Path
+	outputStart := time.Now()
+
+	say(fmt.Sprintf("%.2f", result.Elapsed.Seconds()))
+	say("Goal distance: ", output.DistToGoal())
+
+	if *outFile != "" {
+		opts := sf.options()
+		opts.Runtime, opts.Samples = result.Elapsed, result.Samples
+		if err := writeOutput(output, *outFile, opts); err != nil {
+			return fmt.Errorf("output: %w", err)
+		}
+		say("Image created.")
+	}
+
+	if *modelFile != "" {
+		if err := writeModel(output, *modelFile); err != nil {
+			return fmt.Errorf("model: %w", err)
+		}
+		say("Model created.")
+	}
+
+	if rec != nil {
+		// The final frame shows the finished tree
+		rec.Capture(result.Samples)
+		if err := writeGIF(rec, *gifFile); err != nil {
+			return fmt.Errorf("gif: %w", err)
+		}
+		say("GIF created.")
+	}
+
+	if *saveFile != "" {
+		if err := savePath(output, *saveFile); err != nil {
+			return fmt.Errorf("checkpoint: %w", err)
+		}
+		say("Checkpoint saved.")
+	}
+
+	if tf.out != "" {
+		if err := writeTrajectory(output, tf.out, tf.limits(), float32(tf.dt)); err != nil {
+			return fmt.Errorf("trajectory: %w", err)
+		}
+		say("Trajectory created.")
+	}
+
+	if rf.json() {
+		return newReport(&pf, result, setup, time.Since(outputStart)).write(os.Stdout)
+	}
+	return nil
+}
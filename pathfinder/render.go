@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+)
+
+// Render a saved tree without planning
+func runRender(args []string) error {
+	fs := newFlagSet("render", "Render a tree saved by plan -save without planning.")
+	var sf styleFlags
+	sf.register(fs)
+	configFile := fs.String("config", "", "configuration space `file` (required)")
+	loadFile := fs.String("load", "", "checkpoint `file` written by plan -save (required)")
+	outFile := fs.String("o", "", "output `file` (.jpg, .png, .svg, .geojson or .csv)")
+	modelFile := fs.String("model", "", "write a 3D workspace's tree as a PLY model `file`")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	for _, check := range []error{
+		required("config", *configFile),
+		checkInput("config", *configFile),
+		required("load", *loadFile),
+		checkInput("load", *loadFile),
+		sf.validate(),
+	} {
+		if check != nil {
+			return check
+		}
+	}
+	if *outFile == "" && *modelFile == "" {
+		return fmt.Errorf("-o or -model is required")
+	}
+	if *outFile != "" {
+		if err := checkExt("o", *outFile, outputExts...); err != nil {
+			return err
+		}
+	}
+
+	path, err := loadPath(*configFile, *loadFile)
+	if err != nil {
+		return fmt.Errorf("checkpoint: %w", err)
+	}
+	if *outFile != "" {
+		opts := sf.options()
+		opts.Samples = len(path.MileStones()) - 1
+		if err := writeOutput(path, *outFile, opts); err != nil {
+			return fmt.Errorf("output: %w", err)
+		}
+		fmt.Println("Image created.")
+	}
+	if *modelFile != "" {
+		if err := writeModel(path, *modelFile); err != nil {
+			return fmt.Errorf("model: %w", err)
+		}
+		fmt.Println("Model created.")
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"proj3-redesigned/planner"
+	"time"
+)
+
+// report is the machine-readable result of a run, times are in seconds
+type report struct {
+	Config           string     `json:"config"`
+	Checkpoint       string     `json:"checkpoint,omitempty"`
+	Strategy         string     `json:"strategy"`
+	Threads          int        `json:"threads"`
+	Batch            int        `json:"batch"`
+	Seed             int64      `json:"seed"`
+	Sampler          string     `json:"sampler"`
+	Neighbors        string     `json:"neighbors"`
+	Samples          int        `json:"samples"`
+	WallTime         float64    `json:"wall_time_s"`
+	Phases           phases     `json:"phases"`
+	MileStones       int        `json:"milestones"`
+	Rejected         int        `json:"rejected_samples"`
+	Rewires          int64      `json:"rewires"`
+	SetParentRetries int64      `json:"set_parent_retries"`
+	Solved           bool       `json:"solved"`
+	GoalCost         float32    `json:"goal_cost"`
+	PathLength       float32    `json:"path_length"`
+	Waypoints        []waypoint `json:"waypoints"`
+}
+
+// phases are the times spent in each part of a run. Sampling, rewiring and
+// cost updates are summed over every thread
+type phases struct {
+	Setup      float64 `json:"setup_s"`
+	Plan       float64 `json:"plan_s"`
+	Sample     float64 `json:"sample_s"`
+	Rewire     float64 `json:"rewire_s"`
+	CostUpdate float64 `json:"cost_update_s"`
+	Output     float64 `json:"output_s"`
+}
+
+// waypoint is a state of the solved path
+type waypoint struct {
+	Coords []float32 `json:"coords"`
+	Theta  float32   `json:"theta,omitempty"`
+}
+
+// reportFlag selects how a run is reported
+type reportFlag struct {
+	format string
+}
+
+// Register the report flag
+func (f *reportFlag) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.format, "report", "text", "text, or json to print only a structured report of the run")
+}
+
+// Check the report flag
+func (f *reportFlag) validate() error {
+	if f.format != "text" && f.format != "json" {
+		return fmt.Errorf("-report must be text or json, got %q", f.format)
+	}
+	return nil
+}
+
+// Check if the run is reported as JSON
+func (f *reportFlag) json() bool {
+	return f.format == "json"
+}
+
+// Build the report of a run. Setup is the time spent before planning and
+// output the time spent after it
+func newReport(pf *planFlags, result planner.Result, setup time.Duration, output time.Duration,
+) report {
+	path := result.Path
+	r := report{
+		Config:     pf.config,
+		Checkpoint: pf.load,
+		Strategy:   pf.strategy,
+		Threads:    pf.threads,
+		Batch:      pf.batch,
+		Seed:       pf.seed,
+		Sampler:    pf.sampler,
+		Neighbors:  pf.neighbors,
+		Samples:    result.Samples,
+		WallTime:   (setup + result.Elapsed + output).Seconds(),
+		Phases: phases{
+			Setup:      setup.Seconds(),
+			Plan:       result.Elapsed.Seconds(),
+			Sample:     result.Stats.Sample.Seconds(),
+			Rewire:     result.Stats.Rewire.Seconds(),
+			CostUpdate: result.Stats.CostUpdate.Seconds(),
+			Output:     output.Seconds(),
+		},
+		MileStones:       len(path.MileStones()),
+		Rejected:         len(path.Rejected()),
+		Rewires:          result.Stats.Rewires,
+		SetParentRetries: result.Stats.Retries,
+		Solved:           result.Solved(),
+		GoalCost:         result.Cost,
+		PathLength:       path.Length(),
+		Waypoints:        []waypoint{},
+	}
+	for _, pt := range result.Waypoints {
+		coords := make([]float32, pt.Dim())
+		for i := range coords {
+			coords[i] = pt.Coord(i)
+		}
+		r.Waypoints = append(r.Waypoints, waypoint{coords, pt.Theta})
+	}
+	return r
+}
+
+// Write the report as indented JSON
+func (r report) write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
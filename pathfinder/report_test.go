@@ -0,0 +1,56 @@
+package main
+
+// Unit testing for report.go. Tests the following functions:
+// newReport
+// report.write
+//
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"proj3-redesigned/planner"
+	"testing"
+	"time"
+)
+
+// Test a report describes the run and is written with its JSON names
+func TestReport(t *testing.T) {
+	pf := planFlags{config: "../data/extraeasyMaze.txt", samples: 300, strategy: "sequential",
+		threads: 1, batch: 1, seed: 5, sampler: "uniform", neighbors: "fixed", k: 10}
+	path, err := pf.path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := planner.Continue(context.Background(), path, pf.options(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := newReport(&pf, result, time.Second, 2*time.Second)
+	if r.Samples != 300 || r.MileStones != 301 || r.Seed != 5 {
+		t.Errorf("Expected 300 samples and 301 milestones, got %d and %d", r.Samples, r.MileStones)
+	}
+	if r.WallTime < 3 || r.Phases.Setup != 1 || r.Phases.Output != 2 {
+		t.Errorf("Expected setup and output in the wall time, got %+v", r.Phases)
+	}
+	if r.Solved != (len(r.Waypoints) > 0) || (r.Solved && r.PathLength <= 0) {
+		t.Errorf("Expected waypoints and a length when solved, got %d and %f",
+			len(r.Waypoints), r.PathLength)
+	}
+
+	var buf bytes.Buffer
+	if err := r.write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"config", "wall_time_s", "phases", "rewires", "set_parent_retries",
+		"goal_cost", "path_length", "waypoints"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("Expected %q in the report", key)
+		}
+	}
+}
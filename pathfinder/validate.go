@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"proj3-redesigned/configspace"
+)
+
+// Check config files for problems, listing each one found
+func runValidate(args []string) error {
+	fs := newFlagSet("validate", "Check a configuration space file for problems.")
+	configFile := fs.String("config", "", "configuration space `file` (required)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := required("config", *configFile); err != nil {
+		return err
+	}
+
+	errs := configspace.Validate(*configFile)
+	for _, err := range errs {
+		fmt.Printf("%s: %v\n", *configFile, err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d problems found", len(errs))
+	}
+	fmt.Printf("%s: ok\n", *configFile)
+	return nil
+}
@@ -0,0 +1,122 @@
+package planner
+
+import (
+	"context"
+	"errors"
+	"proj3-redesigned/concurrent"
+	"proj3-redesigned/robotpath"
+	"proj3-redesigned/rrtstar"
+	"proj3-redesigned/sampling"
+)
+
+// Samples per thread in each batch when planning without a sample count
+const batchPerThread = 64
+
+// Tasks a work stealing worker grabs from another at once
+const maxGrab = 100
+
+// Run the pathfinding algorithm in parallel, returns the number of samples
+// drawn and the error of the first failed update. Executors stop early once
+// the context is done, and planning without a sample count runs in batches
+// until then. Work stealing also ends a batch at each observer interval, while
+// BSP observes between supersteps. BSP commits each superstep's milestones in
+// sample order, so with a seeded sampler its result does not depend on timing
+func runParallel(ctx context.Context, path *robotpath.Path, opts Options) (int, error) {
+	n := newNotifier(opts)
+
+	batch := opts.Samples
+	if batch == 0 {
+		batch = opts.Threads * batchPerThread
+	}
+	if opts.Observer != nil && opts.Strategy == "ws" && n.interval < batch {
+		batch = n.interval
+	}
+
+	samples := 0
+	for (opts.Samples == 0 || samples < opts.Samples) && ctx.Err() == nil {
+		size := batch
+		if opts.Samples > 0 && opts.Samples-samples < size {
+			size = opts.Samples - samples
+		}
+
+		// BSP commits each superstep's milestones at the barrier, then
+		// reports the progress
+		done := samples
+		hook := func(superstep int, completed []*rrtstar.PathUpdate) {
+			for _, task := range completed {
+				task.Commit()
+			}
+			if done += len(completed); done < samples+size {
+				n.notify(done)
+			}
+		}
+		ran, err := runTasks(ctx, path, samples, size, opts, hook)
+		samples += ran
+		if err != nil {
+			return samples, err
+		}
+		n.notify(samples)
+	}
+	return samples, nil
+}
+
+// Run updates to the path on a new executor until the context is done, BSP
+// calls the hook between supersteps. The updates are numbered from first,
+// BSP draws the samples of each update from its own stream of the sampler.
+// Returns the number of updates that ran and the error of the first update
+// that failed
+func runTasks(ctx context.Context, path *robotpath.Path, first int, n int, opts Options,
+	hook concurrent.SuperstepHook[rrtstar.PathUpdate],
+) (int, error) {
+	// Initialize executor
+	var executor concurrent.ExecutorService[rrtstar.PathUpdate, any]
+	var newTask func(i int) *rrtstar.PathUpdate
+
+	if opts.Strategy == "ws" {
+		// Work stealing executor
+		executor = concurrent.NewWorkStealingExecutor[rrtstar.PathUpdate](opts.Threads, maxGrab)
+		newTask = func(i int) *rrtstar.PathUpdate { return rrtstar.NewUpdate(path, true) }
+
+	} else if opts.Strategy == "bsp" {
+		// BSP executor
+		bsp := concurrent.NewBSPExecutor[rrtstar.PathUpdate](opts.Threads, hook)
+		if opts.Batch > 0 {
+			bsp.SetBatch(opts.Batch)
+		}
+		executor = bsp
+		splitter, split := path.Sampler.(sampling.Splitter)
+		newTask = func(i int) *rrtstar.PathUpdate {
+			if split {
+				return rrtstar.NewDeferredUpdate(path, splitter.Split(int64(first+i)))
+			}
+			return rrtstar.NewDeferredUpdate(path, path.Sampler)
+		}
+	}
+
+	// Populate the queues with tasks
+	futures := make([]concurrent.Future[any], n)
+	for i := range futures {
+		futures[i] = executor.Submit(newTask(i))
+	}
+
+	// Execute
+	executor.Execute(ctx)
+
+	// Shutdown executor
+	executor.Shutdown()
+
+	// Updates cancelled with the context did not run
+	ran := 0
+	var failed error
+	for _, future := range futures {
+		_, err := future.Get()
+		switch {
+		case err == nil:
+			ran++
+		case ctx.Err() != nil && errors.Is(err, ctx.Err()):
+		case failed == nil:
+			failed = err
+		}
+	}
+	return ran, failed
+}
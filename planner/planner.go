@@ -0,0 +1,182 @@
+package planner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"proj3-redesigned/configspace"
+	"proj3-redesigned/robotpath"
+	"proj3-redesigned/sampling"
+	"time"
+)
+
+// Options configure a planning run
+type Options struct {
+	Samples    int                      // Samples to draw, unlimited under a time budget when zero
+	Strategy   string                   // "sequential", "ws" or "bsp", sequential when empty
+	Threads    int                      // Goroutines used by the parallel strategies
+	Batch      int                      // Samples per BSP worker in each superstep, 1 when zero
+	Seed       int64                    // Seed of the uniform sampler, random when zero
+	Sampler    sampling.Sampler         // Sampler used instead of the uniform one
+	Neighbors  robotpath.NeighborPolicy // Neighbors considered when rewiring, 10 when nil
+	TimeBudget time.Duration            // Time after which no more samples are drawn, unlimited when zero
+	Interval   int                      // Samples between calls to the observer
+	Observer   func(samples int)        // Called at points where no updates are running, may be nil
+}
+
+// Result of a planning run
+type Result struct {
+	Path      *robotpath.Path      // Tree grown by the planner
+	Waypoints []*configspace.Point // Path from start to goal, nil if the goal was not reached
+	Cost      float32              // Cost of the path to the goal
+	Samples   int                  // Samples drawn
+	Elapsed   time.Duration        // Time spent planning
+	Stats     Stats                // Work done during the run
+}
+
+// Stats count the work done during a run. Phase times are summed over every
+// thread, so parallel runs can exceed the elapsed time
+type Stats struct {
+	Rewires    int64         // Parents changed by rewiring
+	Retries    int64         // SetParent attempts retried after a concurrent cost change
+	Sample     time.Duration // Time spent sampling and extending the tree
+	Rewire     time.Duration // Time spent rewiring
+	CostUpdate time.Duration // Time spent propagating costs to descendants
+}
+
+// Read the path's counters
+func statsOf(path *robotpath.Path) Stats {
+	return Stats{
+		Rewires:    path.Stats.Rewires.Load(),
+		Retries:    path.Stats.Retries.Load(),
+		Sample:     time.Duration(path.Stats.SampleTime.Load()),
+		Rewire:     time.Duration(path.Stats.RewireTime.Load()),
+		CostUpdate: time.Duration(path.Stats.CostTime.Load()),
+	}
+}
+
+// Get the work done since an earlier reading
+func (s Stats) since(earlier Stats) Stats {
+	return Stats{
+		Rewires:    s.Rewires - earlier.Rewires,
+		Retries:    s.Retries - earlier.Retries,
+		Sample:     s.Sample - earlier.Sample,
+		Rewire:     s.Rewire - earlier.Rewire,
+		CostUpdate: s.CostUpdate - earlier.CostUpdate,
+	}
+}
+
+// Check if a path to the goal was found
+func (r Result) Solved() bool {
+	return r.Waypoints != nil
+}
+
+// Plan a path through a configuration space. Planning stops after the given
+// number of samples or once the time budget runs out. If the context is
+// cancelled first or a parallel update panics, the partial result is
+// returned along with the error
+func Plan(ctx context.Context, config *configspace.Config, opts Options) (Result, error) {
+	if config.Start == nil || config.Goal == nil {
+		return Result{}, errors.New("configuration space needs a start and a goal")
+	}
+	if err := opts.validate(); err != nil {
+		return Result{}, err
+	}
+	return Continue(ctx, robotpath.NewPathFromConfig(config), opts)
+}
+
+// Continue planning on an existing path, such as one loaded from a checkpoint
+func Continue(ctx context.Context, path *robotpath.Path, opts Options) (Result, error) {
+	if err := opts.validate(); err != nil {
+		return Result{}, err
+	}
+	if opts.Sampler != nil {
+		path.Sampler = opts.Sampler
+	} else if opts.Seed != 0 {
+		path.Sampler = sampling.NewUniform(opts.Seed)
+	}
+	if opts.Neighbors != nil {
+		path.Neighbors = opts.Neighbors
+	}
+
+	// The budget is a deadline on a context of our own, so the caller's
+	// cancellation can be told apart from running out of time
+	runCtx := ctx
+	if opts.TimeBudget > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, opts.TimeBudget)
+		defer cancel()
+	}
+
+	before := statsOf(path)
+	start := time.Now()
+	var samples int
+	var err error
+	if opts.parallel() {
+		samples, err = runParallel(runCtx, path, opts)
+	} else {
+		samples = runSequential(runCtx, path, opts)
+	}
+
+	result := Result{
+		Path:      path,
+		Waypoints: path.Waypoints(),
+		Cost:      path.DistToGoal(),
+		Samples:   samples,
+		Elapsed:   time.Since(start),
+		Stats:     statsOf(path).since(before),
+	}
+	if err != nil {
+		return result, err
+	}
+	return result, ctx.Err()
+}
+
+// Check if the options select a parallel strategy
+func (opts Options) parallel() bool {
+	return opts.Strategy == "ws" || opts.Strategy == "bsp"
+}
+
+// Check the options are consistent
+func (opts Options) validate() error {
+	switch {
+	case opts.Samples < 0:
+		return fmt.Errorf("samples must not be negative, got %d", opts.Samples)
+	case opts.Samples == 0 && opts.TimeBudget <= 0:
+		return errors.New("either samples or a time budget is needed")
+	case opts.Strategy != "" && opts.Strategy != "sequential" && !opts.parallel():
+		return fmt.Errorf("unknown strategy %q, expected sequential, ws or bsp", opts.Strategy)
+	case opts.parallel() && opts.Threads < 2:
+		return fmt.Errorf("%s needs at least 2 threads, got %d", opts.Strategy, opts.Threads)
+	case opts.Batch < 0:
+		return fmt.Errorf("batch must not be negative, got %d", opts.Batch)
+	case opts.Interval < 0:
+		return fmt.Errorf("interval must not be negative, got %d", opts.Interval)
+	}
+	return nil
+}
+
+// notifier calls the observer each time a multiple of the interval is reached
+type notifier struct {
+	observe  func(samples int)
+	interval int
+	next     int
+}
+
+// Create a notifier for the options' observer, every sample is observed when
+// no interval is given
+func newNotifier(opts Options) *notifier {
+	interval := opts.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	return &notifier{observe: opts.Observer, interval: interval, next: interval}
+}
+
+// Call the observer if a multiple of the interval was reached
+func (n *notifier) notify(samples int) {
+	if n.observe != nil && samples >= n.next {
+		n.observe(samples)
+		n.next = (samples/n.interval + 1) * n.interval
+	}
+}
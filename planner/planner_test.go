@@ -0,0 +1,257 @@
+package planner
+
+// Unit testing for planner.go. Tests the following functions:
+// Plan
+// Continue
+// validate
+//
+// Benchmarks the following functions:
+// Plan
+//
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"proj3-redesigned/configspace"
+	"proj3-redesigned/robotpath"
+	"testing"
+	"time"
+)
+
+// Read an open window configuration space
+func openConfig(t *testing.T) *configspace.Config {
+	configPath := filepath.Join(t.TempDir(), "open.txt")
+	config := "window,500,500\nvisibility,60\nstart,20,20\ngoal,480,480\n"
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return configspace.NewConfigSpace(configPath)
+}
+
+// Test inconsistent options are rejected
+func TestValidate(t *testing.T) {
+	invalid := []Options{
+		{Samples: -1},
+		{},
+		{Samples: 10, Strategy: "dfs"},
+		{Samples: 10, Strategy: "ws", Threads: 1},
+		{Samples: 10, Interval: -1},
+		{Samples: 10, Strategy: "bsp", Threads: 2, Batch: -1},
+	}
+	for _, opts := range invalid {
+		if _, err := Plan(context.Background(), openConfig(t), opts); err == nil {
+			t.Errorf("Expected an error for %+v", opts)
+		}
+	}
+	if err := (Options{TimeBudget: time.Second}).validate(); err != nil {
+		t.Errorf("Expected a time budget alone to be valid, got %v", err)
+	}
+}
+
+// Test a seeded sequential run is reproducible and solved
+func TestPlanSeeded(t *testing.T) {
+	opts := Options{Samples: 500, Seed: 3}
+	first, err := Plan(context.Background(), openConfig(t), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := Plan(context.Background(), openConfig(t), opts)
+	if !first.Solved() || first.Samples != 500 {
+		t.Errorf("Expected a solved path from 500 samples, got %d samples", first.Samples)
+	}
+	if first.Cost != second.Cost {
+		t.Errorf("Expected equal costs for the same seed, got %f and %f", first.Cost, second.Cost)
+	}
+}
+
+// Test seeded kinodynamic runs are reproducible, with the random controls
+// following the seed
+func TestPlanSeededKinodynamic(t *testing.T) {
+	for _, opts := range []Options{
+		{Samples: 300, Seed: 7},
+		{Samples: 300, Seed: 7, Strategy: "bsp", Threads: 4},
+	} {
+		first, err := Plan(context.Background(), configspace.NewConfigSpace("../data/kinodynamicExample.txt"), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, _ := Plan(context.Background(), configspace.NewConfigSpace("../data/kinodynamicExample.txt"), opts)
+		if first.Cost != second.Cost || !samePoints(first.Path.MileStones(), second.Path.MileStones()) {
+			t.Errorf("Expected equal runs with %q for the same seed, got costs %f and %f",
+				opts.Strategy, first.Cost, second.Cost)
+		}
+	}
+}
+
+// Check if two lists of milestones are at the same states
+func samePoints(a []*robotpath.MileStone, b []*robotpath.MileStone) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		for axis := 0; axis < a[i].Point.Dim(); axis++ {
+			if a[i].Point.Coord(axis) != b[i].Point.Coord(axis) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Test the parallel strategies draw every sample and add every milestone
+func TestPlanParallel(t *testing.T) {
+	for _, strategy := range []string{"ws", "bsp"} {
+		opts := Options{Samples: 400, Strategy: strategy, Threads: 4}
+		result, err := Plan(context.Background(), openConfig(t), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Samples != 400 {
+			t.Errorf("Expected 400 samples with %s, got %d", strategy, result.Samples)
+		}
+		if len(result.Path.MileStones()) != 401 {
+			t.Errorf("Expected 401 milestones with %s, got %d", strategy, len(result.Path.MileStones()))
+		}
+	}
+}
+
+// Test seeded BSP runs are reproducible whatever the threads, batch and
+// timing of the updates
+func TestPlanBSPSeeded(t *testing.T) {
+	// The runs of each group plan as many samples against each superstep's tree
+	groups := [][]Options{
+		{{Threads: 4, Batch: 1}, {Threads: 4, Batch: 1}, {Threads: 2, Batch: 2}},
+		{{Threads: 8, Batch: 5}, {Threads: 8, Batch: 5}, {Threads: 4, Batch: 10}},
+	}
+	for _, group := range groups {
+		var first Result
+		for i, opts := range group {
+			opts.Samples, opts.Strategy, opts.Seed = 500, "bsp", 3
+			result, err := Plan(context.Background(), openConfig(t), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.Solved() || len(result.Path.MileStones()) != 501 {
+				t.Errorf("Expected a solved path of 501 milestones with %d threads and batch %d, got %d",
+					opts.Threads, opts.Batch, len(result.Path.MileStones()))
+			}
+			if i == 0 {
+				first = result
+			} else if result.Cost != first.Cost || result.Stats.Rewires != first.Stats.Rewires ||
+				!samePoints(result.Path.MileStones(), first.Path.MileStones()) {
+				t.Errorf("Expected %d threads and batch %d to repeat the group's result, "+
+					"got cost %f and %d rewires, want %f and %d", opts.Threads, opts.Batch,
+					result.Cost, result.Stats.Rewires, first.Cost, first.Stats.Rewires)
+			}
+		}
+	}
+}
+
+// Test a time budget ends a run without a sample count
+func TestPlanTimeBudget(t *testing.T) {
+	for _, strategy := range []string{"sequential", "ws", "bsp"} {
+		opts := Options{Strategy: strategy, Threads: 2, TimeBudget: 50 * time.Millisecond}
+		result, err := Plan(context.Background(), openConfig(t), opts)
+		if err != nil {
+			t.Errorf("Expected no error once the budget runs out, got %v", err)
+		}
+		if result.Samples == 0 || result.Elapsed > time.Second {
+			t.Errorf("Expected %s to sample until the budget, got %d samples in %v",
+				strategy, result.Samples, result.Elapsed)
+		}
+	}
+}
+
+// Test cancelling the context returns the partial result with its error
+func TestPlanCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	opts := Options{Samples: 1000000, Interval: 100, Observer: func(samples int) {
+		if samples >= 200 {
+			cancel()
+		}
+	}}
+	result, err := Plan(ctx, openConfig(t), opts)
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if result.Path == nil || result.Samples < 200 || result.Samples >= 1000000 {
+		t.Errorf("Expected a partial result, got %d samples", result.Samples)
+	}
+}
+
+// Test cancelling a parallel run keeps the milestones of every update that ran
+func TestPlanCancelParallel(t *testing.T) {
+	for _, strategy := range []string{"ws", "bsp"} {
+		ctx, cancel := context.WithCancel(context.Background())
+		opts := Options{Samples: 20000, Strategy: strategy, Threads: 4, Interval: 100,
+			Observer: func(samples int) {
+				if samples >= 200 {
+					cancel()
+				}
+			}}
+		result, err := Plan(ctx, openConfig(t), opts)
+		if err != context.Canceled {
+			t.Errorf("Expected context.Canceled with %s, got %v", strategy, err)
+		}
+		if result.Samples < 200 || result.Samples >= 20000 ||
+			len(result.Path.MileStones()) != result.Samples+1 {
+			t.Errorf("Expected a milestone for each of the partial samples with %s, got %d and %d",
+				strategy, result.Samples, len(result.Path.MileStones()))
+		}
+	}
+}
+
+// Test the observer is called at each interval
+func TestPlanObserver(t *testing.T) {
+	for _, strategy := range []string{"sequential", "ws", "bsp"} {
+		var calls []int
+		opts := Options{
+			Samples: 400, Strategy: strategy, Threads: 4, Interval: 100,
+			Observer: func(samples int) { calls = append(calls, samples) },
+		}
+		if _, err := Plan(context.Background(), openConfig(t), opts); err != nil {
+			t.Fatal(err)
+		}
+		if len(calls) != 4 || calls[len(calls)-1] != 400 {
+			t.Errorf("Expected %s to be observed every 100 samples, got %v", strategy, calls)
+		}
+	}
+}
+
+// Test continuing a path keeps its tree and uses the given neighbor policy
+func TestContinue(t *testing.T) {
+	path := robotpath.NewPathFromConfig(openConfig(t))
+	opts := Options{Samples: 100, Neighbors: robotpath.LogNeighbors()}
+	if _, err := Continue(context.Background(), path, opts); err != nil {
+		t.Fatal(err)
+	}
+	result, _ := Continue(context.Background(), path, opts)
+	if len(result.Path.MileStones()) != 201 {
+		t.Errorf("Expected 201 milestones, got %d", len(result.Path.MileStones()))
+	}
+}
+
+// Benchmark full runs of each strategy on the extra easy maze with the same
+// samples drawn
+func BenchmarkPlan(b *testing.B) {
+	config := configspace.NewConfigSpace("../data/extraeasyMaze.txt")
+	runs := []Options{
+		{Strategy: "sequential", Threads: 1},
+		{Strategy: "ws", Threads: 2},
+		{Strategy: "ws", Threads: 4},
+		{Strategy: "bsp", Threads: 2},
+		{Strategy: "bsp", Threads: 4},
+	}
+	for _, opts := range runs {
+		opts.Samples, opts.Seed = 1000, 1
+		b.Run(fmt.Sprintf("%s/%d", opts.Strategy, opts.Threads), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := Plan(context.Background(), config, opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
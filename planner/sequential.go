@@ -0,0 +1,26 @@
+package planner
+
+import (
+	"context"
+	"proj3-redesigned/robotpath"
+	"proj3-redesigned/rrtstar"
+)
+
+// Run the pathfinding algorithm sequentially until the samples are drawn or
+// the context is done, returns the number of samples drawn
+func runSequential(ctx context.Context, path *robotpath.Path, opts Options) int {
+	n := newNotifier(opts)
+
+	// Make updates to the path using the RRT* algorithm
+	samples := 0
+	for opts.Samples == 0 || samples < opts.Samples {
+		if ctx.Err() != nil {
+			break
+		}
+		task := rrtstar.NewUpdate(path, true)
+		task.Run()
+		samples++
+		n.notify(samples)
+	}
+	return samples
+}
@@ -0,0 +1,118 @@
+package recorder
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+	"math"
+	"proj3-redesigned/robotpath"
+
+	"github.com/fogleman/gg"
+)
+
+// Width frames are scaled down to by default
+const defaultWidth = 800
+
+// Delays in 100ths of a second, the last frame is held longer
+const (
+	frameDelay = 10
+	lastDelay  = 200
+)
+
+// Recorder captures frames of a path as its tree grows and writes them as an
+// animated GIF. Frames must be captured while no updates are running, so the
+// tree is in a consistent state
+type Recorder struct {
+	Path     *robotpath.Path // Path being recorded
+	Interval int             // Samples between frames
+	Scale    float64         // Frame size relative to the window
+	frames   []*image.Paletted
+	next     int // Samples at which the next frame is due
+	last     int // Samples at the last frame
+}
+
+// Create a new Recorder capturing a frame every interval samples. A zero scale
+// fits the frames to 800 pixels wide
+func New(path *robotpath.Path, interval int, scale float64) *Recorder {
+	if interval < 1 {
+		interval = 1
+	}
+	if scale <= 0 {
+		scale = math.Min(1, defaultWidth/float64(path.Config.WinWidth+path.Config.WinDepth))
+	}
+	return &Recorder{
+		Path:     path,
+		Interval: interval,
+		Scale:    scale,
+		next:     interval,
+		last:     -1,
+	}
+}
+
+// Capture a frame if a multiple of the interval was reached
+func (r *Recorder) Observe(samples int) {
+	if samples >= r.next {
+		r.Capture(samples)
+		r.next = (samples/r.Interval + 1) * r.Interval
+	}
+}
+
+// Capture a frame labelled with the number of samples, unless one was already
+// captured at that count
+func (r *Recorder) Capture(samples int) {
+	if samples == r.last {
+		return
+	}
+	r.last = samples
+
+	img := r.Path.Render(robotpath.RenderOptions{Scale: r.Scale})
+	screen := gg.NewContextForRGBA(img)
+	label := "goal not reached"
+	if r.Path.Goal.Parent != nil {
+		label = fmt.Sprintf("goal %.1f", r.Path.DistToGoal())
+	}
+	label = fmt.Sprintf("%d samples, %s", samples, label)
+	width, height := screen.MeasureString(label)
+	screen.SetColor(color.White)
+	screen.DrawRectangle(0, 0, width+10, height+10)
+	screen.Fill()
+	screen.SetColor(color.Black)
+	screen.DrawString(label, 5, height+5)
+
+	frame := image.NewPaletted(img.Bounds(), framePalette)
+	draw.Draw(frame, frame.Rect, img, image.Point{}, draw.Src)
+	r.frames = append(r.frames, frame)
+}
+
+// Get the number of captured frames
+func (r *Recorder) Frames() int {
+	return len(r.frames)
+}
+
+// Write the captured frames as an animated GIF
+func (r *Recorder) WriteGIF(w io.Writer) error {
+	if len(r.frames) == 0 {
+		return fmt.Errorf("no frames captured")
+	}
+	delays := make([]int, len(r.frames))
+	for i := range delays {
+		delays[i] = frameDelay
+	}
+	delays[len(delays)-1] = lastDelay
+	return gif.EncodeAll(w, &gif.GIF{Image: r.frames, Delay: delays})
+}
+
+// Palette of the colors the path is drawn with, followed by a general palette
+// for antialiased edges
+var framePalette = append(color.Palette{
+	color.White,
+	color.Black,
+	color.RGBA{R: 173, G: 216, B: 230, A: 255},
+	color.RGBA{R: 139, G: 0, B: 0, A: 255},
+	color.RGBA{R: 0, G: 100, B: 0, A: 255},
+	color.Gray{Y: 128},
+}, palette.Plan9[:250]...)
@@ -0,0 +1,81 @@
+package recorder
+
+// Unit testing for recorder.go. Tests the following functions:
+// New
+// Observe
+// Capture
+// WriteGIF
+//
+
+import (
+	"bytes"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"proj3-redesigned/robotpath"
+	"testing"
+)
+
+// Create a path in an empty 1600x1600 window
+func testPath(t *testing.T) *robotpath.Path {
+	configPath := filepath.Join(t.TempDir(), "open.txt")
+	config := "window,1600,1600\nvisibility,20\nstart,10,10\ngoal,90,90\n"
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return robotpath.NewPath(configPath)
+}
+
+// Test frames are captured at each interval and scaled to fit
+func TestObserve(t *testing.T) {
+	rec := New(testPath(t), 10, 0)
+	if rec.Scale != 0.5 {
+		t.Errorf("expected scale 0.5, got %v", rec.Scale)
+	}
+	for samples := 1; samples <= 35; samples++ {
+		rec.Observe(samples)
+	}
+	if rec.Frames() != 3 {
+		t.Errorf("expected 3 frames, got %d", rec.Frames())
+	}
+
+	// Observations skipping past an interval capture once
+	rec.Observe(52)
+	rec.Observe(55)
+	rec.Observe(60)
+	if rec.Frames() != 5 {
+		t.Errorf("expected 5 frames, got %d", rec.Frames())
+	}
+
+	// A final capture at the last count is not repeated
+	rec.Capture(60)
+	rec.Capture(61)
+	if rec.Frames() != 6 {
+		t.Errorf("expected 6 frames, got %d", rec.Frames())
+	}
+}
+
+// Test the written GIF has every frame and holds the last one
+func TestWriteGIF(t *testing.T) {
+	rec := New(testPath(t), 1, 0.1)
+	var buf bytes.Buffer
+	if err := rec.WriteGIF(&buf); err == nil {
+		t.Error("expected an error without frames")
+	}
+
+	rec.Capture(1)
+	rec.Capture(2)
+	if err := rec.WriteGIF(&buf); err != nil {
+		t.Fatal(err)
+	}
+	g, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Image) != 2 || g.Image[0].Bounds().Dx() != 160 {
+		t.Errorf("expected 2 frames 160 wide, got %d", len(g.Image))
+	}
+	if g.Delay[0] != frameDelay || g.Delay[1] != lastDelay {
+		t.Errorf("unexpected delays %v", g.Delay)
+	}
+}
@@ -0,0 +1,124 @@
+package robotpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"proj3-redesigned/configspace"
+)
+
+// Checkpoint is the serialized form of a Path. The configuration space is
+// referenced by its file, so obstacles changed while replanning are not kept
+type Checkpoint struct {
+	ConfigPath string           `json:"config"`
+	Start      int              `json:"start"`
+	Goal       CheckpointNode   `json:"goal"`
+	MileStones []CheckpointNode `json:"milestones"`
+}
+
+// CheckpointNode is a serialized milestone, parents are given by their index
+// in the milestone list with -1 for none
+type CheckpointNode struct {
+	Coords []float32 `json:"coords"`
+	Theta  float32   `json:"theta,omitempty"`
+	Parent int       `json:"parent"`
+	Dist   float32   `json:"dist"`
+	Cost   float32   `json:"cost"`
+}
+
+// Save writes the path's tree as a JSON checkpoint. Must not be called while
+// updates are running on the path
+func (path *Path) Save(w io.Writer) error {
+	milestones := path.MileStones()
+	index := make(map[*MileStone]int, len(milestones))
+	for i, ms := range milestones {
+		index[ms] = i
+	}
+
+	node := func(ms *MileStone) CheckpointNode {
+		coords := make([]float32, ms.Point.Dim())
+		for i := range coords {
+			coords[i] = ms.Point.Coord(i)
+		}
+		parent := -1
+		if ms.Parent != nil {
+			parent = index[ms.Parent]
+		}
+		return CheckpointNode{coords, ms.Point.Theta, parent, ms.dist, ms.Cost}
+	}
+
+	checkpoint := Checkpoint{
+		ConfigPath: path.ConfigPath,
+		Start:      index[path.Start],
+		Goal:       node(path.Goal),
+		MileStones: make([]CheckpointNode, len(milestones)),
+	}
+	for i, ms := range milestones {
+		checkpoint.MileStones[i] = node(ms)
+	}
+	return json.NewEncoder(w).Encode(checkpoint)
+}
+
+// Load reads a path from a JSON checkpoint. The configuration space is read
+// from configPath, or from the file recorded in the checkpoint when empty
+func Load(r io.Reader, configPath string) (*Path, error) {
+	var checkpoint Checkpoint
+	if err := json.NewDecoder(r).Decode(&checkpoint); err != nil {
+		return nil, err
+	}
+	if configPath == "" {
+		configPath = checkpoint.ConfigPath
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		return nil, err
+	}
+	n := len(checkpoint.MileStones)
+	if checkpoint.Start < 0 || checkpoint.Start >= n {
+		return nil, fmt.Errorf("start index %d out of range", checkpoint.Start)
+	}
+
+	path := NewPath(configPath)
+	milestones := make([]*MileStone, n)
+	for i, node := range checkpoint.MileStones {
+		milestones[i] = node.mileStone()
+	}
+	goal := checkpoint.Goal.mileStone()
+
+	// Link each milestone to its parent
+	link := func(ms *MileStone, node CheckpointNode) error {
+		if node.Parent < 0 {
+			return nil
+		}
+		if node.Parent >= n {
+			return fmt.Errorf("parent index %d out of range", node.Parent)
+		}
+		ms.Parent = milestones[node.Parent]
+		ms.Parent.setChild(ms)
+		return nil
+	}
+	for i, node := range checkpoint.MileStones {
+		if err := link(milestones[i], node); err != nil {
+			return nil, err
+		}
+	}
+	if err := link(goal, checkpoint.Goal); err != nil {
+		return nil, err
+	}
+
+	path.milestones = milestones
+	path.Start = milestones[checkpoint.Start]
+	path.Goal = goal
+	path.Config.Start = path.Start.Point
+	return path, nil
+}
+
+// Create the milestone described by a node, without its parent
+func (node CheckpointNode) mileStone() *MileStone {
+	pt := configspace.NewPointN(node.Coords)
+	pt.Theta = node.Theta
+	ms := NewMileStone(pt)
+	ms.dist = node.Dist
+	ms.Cost = node.Cost
+	return ms
+}
@@ -0,0 +1,79 @@
+package robotpath
+
+// Unit testing for checkpoint.go. Tests the following functions:
+// Save
+// Load
+//
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"proj3-redesigned/configspace"
+	"testing"
+)
+
+// Build a small solved path: start -> a -> b -> goal, with c branching off a
+func testPath(t *testing.T) *Path {
+	configPath := filepath.Join(t.TempDir(), "open.txt")
+	config := "window,100,100\nvisibility,20\nstart,10,10\ngoal,90,90\nrectangle,50,20,10,30\n"
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := NewPath(configPath)
+	a := NewMileStone(&configspace.Point{X: 30, Y: 30, Theta: 0.5})
+	b := NewMileStone(&configspace.Point{X: 70, Y: 70})
+	c := NewMileStone(&configspace.Point{X: 30, Y: 60})
+	a.SetParent(path.Start, 0, 28)
+	b.SetParent(a, 0, 57)
+	c.SetParent(a, 0, 30)
+	path.Goal.SetParent(b, 0, 28)
+	for _, ms := range []*MileStone{a, b, c} {
+		path.AddPoint(ms)
+	}
+	return path
+}
+
+// Test a saved tree is loaded with the same milestones, links and costs
+func TestCheckpointRoundTrip(t *testing.T) {
+	path := testPath(t)
+
+	var buf bytes.Buffer
+	if err := path.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(&buf, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.ConfigPath != path.ConfigPath {
+		t.Errorf("expected config %s, got %s", path.ConfigPath, loaded.ConfigPath)
+	}
+	milestones := loaded.MileStones()
+	if len(milestones) != 4 || loaded.Start != milestones[0] {
+		t.Fatalf("expected 4 milestones rooted at the first, got %d", len(milestones))
+	}
+	la, lb, lc := milestones[1], milestones[2], milestones[3]
+	if la.Parent != loaded.Start || lb.Parent != la || lc.Parent != la || loaded.Goal.Parent != lb {
+		t.Error("parent links were not restored")
+	}
+	if _, ok := la.Children.Load(lc); !ok {
+		t.Error("child links were not restored")
+	}
+	if la.Point.Theta != 0.5 || lc.Point.Y != 60 {
+		t.Errorf("points were not restored, got %+v and %+v", *la.Point, *lc.Point)
+	}
+	if loaded.DistToGoal() != 113 || lc.Dist() != 30 {
+		t.Errorf("expected goal cost 113, got %v", loaded.DistToGoal())
+	}
+}
+
+// Test loading fails without a readable configuration space
+func TestLoadMissingConfig(t *testing.T) {
+	checkpoint := `{"config":"missing.txt","start":0,"milestones":[{"coords":[0,0],"parent":-1}]}`
+	if _, err := Load(bytes.NewBufferString(checkpoint), ""); err == nil {
+		t.Error("expected an error for a missing config")
+	}
+}
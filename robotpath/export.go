@@ -0,0 +1,184 @@
+package robotpath
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+	"proj3-redesigned/configspace"
+	"strconv"
+	"strings"
+)
+
+// Vector exports are a top view in window coordinates, with y pointing down as
+// in the rendered images. Moving obstacles and obstacles without an outline are
+// left out
+
+// Get the obstacle outlines that can be exported
+func (path *Path) outlines() [][]*configspace.Point {
+	var outlines [][]*configspace.Point
+	for _, o := range path.Config.Obstacles {
+		if outliner, ok := o.(configspace.Outliner); ok {
+			outlines = append(outlines, outliner.Outline())
+		}
+	}
+	return outlines
+}
+
+// Format a color as an SVG hex color
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// Format points as an SVG list of coordinates
+func svgPoints(points []*configspace.Point) string {
+	coords := make([]string, len(points))
+	for i, pt := range points {
+		coords[i] = fmt.Sprintf("%g,%g", pt.X, pt.Y)
+	}
+	return strings.Join(coords, " ")
+}
+
+// WriteSVG writes the obstacles, tree and optimal path as an SVG image styled
+// like the rendered images
+func (path *Path) WriteSVG(w io.Writer) error {
+	milestones, _ := path.treeMileStones()
+	onPath := path.onOptimalPath()
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%g\" height=\"%g\" viewBox=\"0 0 %g %g\">\n",
+		path.Config.WinWidth, path.Config.WinHeight, path.Config.WinWidth, path.Config.WinHeight)
+	fmt.Fprintln(out, "<rect width=\"100%\" height=\"100%\" fill=\"white\"/>")
+
+	fmt.Fprintln(out, "<g id=\"obstacles\" fill=\"black\">")
+	for _, outline := range path.outlines() {
+		fmt.Fprintf(out, "<polygon points=\"%s\"/>\n", svgPoints(outline))
+	}
+	fmt.Fprintln(out, "</g>")
+
+	// Optimal path edges are drawn after the tree so they stay on top
+	for _, group := range []struct {
+		id      string
+		stroke  color.RGBA
+		width   int
+		optimal bool
+	}{{"tree", lightBlue, 5, false}, {"solution", darkGreen, 6, true}} {
+		fmt.Fprintf(out, "<g id=\"%s\" fill=\"none\" stroke=\"%s\" stroke-width=\"%d\">\n",
+			group.id, hexColor(group.stroke), group.width)
+		for _, ms := range milestones {
+			if ms.Parent != nil && onPath[ms] == group.optimal {
+				states := path.Steering.Interpolate(ms.Parent.Point, ms.Point)
+				fmt.Fprintf(out, "<polyline points=\"%s\"/>\n", svgPoints(states))
+			}
+		}
+		fmt.Fprintln(out, "</g>")
+	}
+
+	fmt.Fprintf(out, "<circle cx=\"%g\" cy=\"%g\" r=\"5\" fill=\"%s\"/>\n",
+		path.Start.Point.X, path.Start.Point.Y, hexColor(darkGreen))
+	fmt.Fprintf(out, "<circle cx=\"%g\" cy=\"%g\" r=\"5\" fill=\"%s\"/>\n",
+		path.Goal.Point.X, path.Goal.Point.Y, hexColor(darkRed))
+	fmt.Fprintln(out, "</svg>")
+	return out.Flush()
+}
+
+// geoJSONFeature is a GeoJSON feature with a kind property
+type geoJSONFeature struct {
+	Type       string         `json:"type"`
+	Geometry   geoJSONGeom    `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// geoJSONGeom is a GeoJSON geometry
+type geoJSONGeom struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// Get the GeoJSON positions of points
+func positions(points []*configspace.Point) [][2]float32 {
+	coords := make([][2]float32, len(points))
+	for i, pt := range points {
+		coords[i] = [2]float32{pt.X, pt.Y}
+	}
+	return coords
+}
+
+// WriteGeoJSON writes the obstacles, tree, optimal path, start and goal as a
+// GeoJSON feature collection, each feature's kind property says which it is
+func (path *Path) WriteGeoJSON(w io.Writer) error {
+	milestones, _ := path.treeMileStones()
+	var features []geoJSONFeature
+	feature := func(kind string, geomType string, coords any) *geoJSONFeature {
+		features = append(features, geoJSONFeature{
+			Type:       "Feature",
+			Geometry:   geoJSONGeom{geomType, coords},
+			Properties: map[string]any{"kind": kind},
+		})
+		return &features[len(features)-1]
+	}
+
+	// Polygon rings are closed by repeating the first position
+	for _, outline := range path.outlines() {
+		ring := positions(append(outline, outline[0]))
+		feature("obstacle", "Polygon", [][][2]float32{ring})
+	}
+
+	// An empty MultiLineString still needs its coordinates array
+	edges := [][][2]float32{}
+	for _, ms := range milestones {
+		if ms.Parent != nil {
+			edges = append(edges, positions(path.Steering.Interpolate(ms.Parent.Point, ms.Point)))
+		}
+	}
+	feature("tree", "MultiLineString", edges).Properties["milestones"] = len(milestones)
+
+	if waypoints := path.Waypoints(); waypoints != nil {
+		var line []*configspace.Point
+		for i := 1; i < len(waypoints); i++ {
+			states := path.Steering.Interpolate(waypoints[i-1], waypoints[i])
+			if i > 1 {
+				states = states[1:]
+			}
+			line = append(line, states...)
+		}
+		feature("solution", "LineString", positions(line)).Properties["cost"] = path.DistToGoal()
+	}
+
+	feature("start", "Point", [2]float32{path.Start.Point.X, path.Start.Point.Y})
+	feature("goal", "Point", [2]float32{path.Goal.Point.X, path.Goal.Point.Y})
+
+	return json.NewEncoder(w).Encode(map[string]any{
+		"type":     "FeatureCollection",
+		"features": features,
+	})
+}
+
+// WriteCSV writes the tree as an edge list, one row per milestone with a
+// parent giving both endpoints, the child's cost and whether the edge is on
+// the optimal path
+func (path *Path) WriteCSV(w io.Writer) error {
+	milestones, index := path.treeMileStones()
+	onPath := path.onOptimalPath()
+	format := func(value float32) string {
+		return strconv.FormatFloat(float64(value), 'g', -1, 32)
+	}
+
+	out := csv.NewWriter(w)
+	out.Write([]string{"parent", "child", "x1", "y1", "x2", "y2", "cost", "optimal"})
+	for _, ms := range milestones {
+		if ms.Parent == nil {
+			continue
+		}
+		out.Write([]string{
+			strconv.Itoa(index[ms.Parent]), strconv.Itoa(index[ms]),
+			format(ms.Parent.Point.X), format(ms.Parent.Point.Y),
+			format(ms.Point.X), format(ms.Point.Y),
+			format(ms.Cost), strconv.FormatBool(onPath[ms]),
+		})
+	}
+	out.Flush()
+	return out.Error()
+}
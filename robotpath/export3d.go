@@ -0,0 +1,112 @@
+package robotpath
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Get the milestones of the tree including the goal once reached, along with
+// each milestone's index in that list
+func (path *Path) treeMileStones() ([]*MileStone, map[*MileStone]int) {
+	path.rw.RLock()
+	milestones := append([]*MileStone{}, path.milestones...)
+	path.rw.RUnlock()
+	if path.Goal.Parent != nil {
+		milestones = append(milestones, path.Goal)
+	}
+
+	index := make(map[*MileStone]int, len(milestones))
+	for i, ms := range milestones {
+		index[ms] = i
+	}
+	return milestones, index
+}
+
+// Check which milestones lie on the optimal path
+func (path *Path) onOptimalPath() map[*MileStone]bool {
+	onPath := make(map[*MileStone]bool)
+	for ms := path.Goal; ms.Parent != nil; ms = ms.Parent {
+		onPath[ms], onPath[ms.Parent] = true, true
+	}
+	return onPath
+}
+
+// Get the 3D position of a milestone, planar milestones lie at zero height
+func position(ms *MileStone) (float32, float32, float32) {
+	var z float32
+	if ms.Point.Dim() > 2 {
+		z = ms.Point.Coord(2)
+	}
+	return ms.Point.X, ms.Point.Y, z
+}
+
+// WritePLY writes the tree as an ASCII PLY model of vertices and edges, with
+// the optimal path colored green
+func (path *Path) WritePLY(w io.Writer) error {
+	milestones, index := path.treeMileStones()
+	onPath := path.onOptimalPath()
+
+	var edges []*MileStone
+	for _, ms := range milestones {
+		if ms.Parent != nil {
+			edges = append(edges, ms)
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "ply")
+	fmt.Fprintln(out, "format ascii 1.0")
+	fmt.Fprintf(out, "element vertex %d\n", len(milestones))
+	fmt.Fprintln(out, "property float x")
+	fmt.Fprintln(out, "property float y")
+	fmt.Fprintln(out, "property float z")
+	fmt.Fprintf(out, "element edge %d\n", len(edges))
+	fmt.Fprintln(out, "property int vertex1")
+	fmt.Fprintln(out, "property int vertex2")
+	fmt.Fprintln(out, "property uchar red")
+	fmt.Fprintln(out, "property uchar green")
+	fmt.Fprintln(out, "property uchar blue")
+	fmt.Fprintln(out, "end_header")
+
+	for _, ms := range milestones {
+		x, y, z := position(ms)
+		fmt.Fprintf(out, "%g %g %g\n", x, y, z)
+	}
+	for _, ms := range edges {
+		c := lightBlue
+		if onPath[ms] {
+			c = darkGreen
+		}
+		fmt.Fprintf(out, "%d %d %d %d %d\n", index[ms.Parent], index[ms], c.R, c.G, c.B)
+	}
+	return out.Flush()
+}
+
+// WriteOBJ writes the tree as a Wavefront OBJ model of vertices and line
+// elements, with the optimal path in its own group
+func (path *Path) WriteOBJ(w io.Writer) error {
+	milestones, index := path.treeMileStones()
+	onPath := path.onOptimalPath()
+
+	out := bufio.NewWriter(w)
+	for _, ms := range milestones {
+		x, y, z := position(ms)
+		fmt.Fprintf(out, "v %g %g %g\n", x, y, z)
+	}
+
+	// OBJ indices start at one
+	fmt.Fprintln(out, "g tree")
+	for _, ms := range milestones {
+		if ms.Parent != nil && !onPath[ms] {
+			fmt.Fprintf(out, "l %d %d\n", index[ms.Parent]+1, index[ms]+1)
+		}
+	}
+	fmt.Fprintln(out, "g solution")
+	for _, ms := range milestones {
+		if ms.Parent != nil && onPath[ms] {
+			fmt.Fprintf(out, "l %d %d\n", index[ms.Parent]+1, index[ms]+1)
+		}
+	}
+	return out.Flush()
+}
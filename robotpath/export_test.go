@@ -0,0 +1,101 @@
+package robotpath
+
+// Unit testing for export.go. Tests the following functions:
+// WriteSVG
+// WriteGeoJSON
+// WriteCSV
+//
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Test the SVG has the obstacle, every edge and both endpoints
+func TestWriteSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testPath(t).WriteSVG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	if !strings.Contains(svg, `<polygon points="50,20 80,20 80,30 50,30"/>`) {
+		t.Error("obstacle outline missing")
+	}
+	if n := strings.Count(svg, "<polyline"); n != 4 {
+		t.Errorf("expected 4 edges, got %d", n)
+	}
+	if n := strings.Count(svg, "<circle"); n != 2 {
+		t.Errorf("expected start and goal, got %d circles", n)
+	}
+}
+
+// Test the GeoJSON features and their kinds
+func TestWriteGeoJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testPath(t).WriteGeoJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var collection struct {
+		Type     string
+		Features []struct {
+			Geometry struct {
+				Type        string
+				Coordinates json.RawMessage
+			}
+			Properties map[string]any
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &collection); err != nil {
+		t.Fatal(err)
+	}
+	var kinds []string
+	for _, f := range collection.Features {
+		kinds = append(kinds, f.Properties["kind"].(string)+":"+f.Geometry.Type)
+	}
+	expected := "obstacle:Polygon tree:MultiLineString solution:LineString start:Point goal:Point"
+	if collection.Type != "FeatureCollection" || strings.Join(kinds, " ") != expected {
+		t.Errorf("expected %s, got %v", expected, kinds)
+	}
+	if cost := collection.Features[2].Properties["cost"]; cost != 113.0 {
+		t.Errorf("expected solution cost 113, got %v", cost)
+	}
+
+	// A tree of only the start has no edges
+	buf.Reset()
+	if err := NewPathFromConfig(testPath(t).Config).WriteGeoJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(buf.Bytes(), &collection); err != nil {
+		t.Fatal(err)
+	}
+	if tree := collection.Features[1]; string(tree.Geometry.Coordinates) != "[]" {
+		t.Errorf("expected empty tree coordinates, got %s", tree.Geometry.Coordinates)
+	}
+}
+
+// Test the CSV has a row per edge with the optimal ones marked
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testPath(t).WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("expected a header and 4 edges, got %d rows", len(rows))
+	}
+	optimal := 0
+	for _, row := range rows[1:] {
+		if row[7] == "true" {
+			optimal++
+		}
+	}
+	if optimal != 3 || strings.Join(rows[4], ",") != "2,4,70,70,90,90,113,true" {
+		t.Errorf("unexpected edges %v", rows[1:])
+	}
+}
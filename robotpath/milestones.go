@@ -53,6 +53,24 @@ func (ms *MileStone) SetParent(newParent *MileStone, curCost float32, dist float
 	return true
 }
 
+// Detach a milestone from its parent, leaving it without a cost
+func (ms *MileStone) Detach() {
+	ms.costLock.Lock()
+	defer ms.costLock.Unlock()
+
+	if ms.Parent != nil {
+		ms.Parent.removeChild(ms)
+	}
+	ms.Parent = nil
+	ms.dist = 0
+	ms.Cost = 0
+}
+
+// Get the distance to the milestone's parent
+func (ms *MileStone) Dist() float32 {
+	return ms.dist
+}
+
 // Add a child to a milestone
 func (ms *MileStone) setChild(child *MileStone) {
 	ms.Children.Store(child, child)
@@ -0,0 +1,32 @@
+package robotpath
+
+// Benchmarks for milestones.go. Benchmarks the following functions:
+// UpdateChildrenCost
+//
+
+import (
+	"fmt"
+	"proj3-redesigned/configspace"
+	"testing"
+)
+
+// Benchmark UpdateChildrenCost from the root of chains of increasing depth,
+// the worst case for propagating a rewired cost
+func BenchmarkUpdateChildrenCost(b *testing.B) {
+	for _, depth := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprint(depth), func(b *testing.B) {
+			root := NewMileStone(&configspace.Point{})
+			ms := root
+			for i := 1; i < depth; i++ {
+				child := NewMileStone(&configspace.Point{X: float32(i)})
+				child.SetParent(ms, 0, 1)
+				ms = child
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				root.Cost = float32(i % 2)
+				root.UpdateChildrenCost()
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package robotpath
+
+import "math"
+
+// Neighbors considered when rewiring by default
+const defaultNeighbors = 10
+
+// NeighborPolicy gives the number of nearest neighbors considered when
+// rewiring a tree of n milestones in a space of the given dimension
+type NeighborPolicy func(n int, dim int) int
+
+// FixedNeighbors considers the same number of neighbors at every tree size
+func FixedNeighbors(k int) NeighborPolicy {
+	return func(n int, dim int) int {
+		return k
+	}
+}
+
+// LogNeighbors considers e(1 + 1/d) log n neighbors, the k-nearest RRT* rule
+// from Karaman and Frazzoli that keeps the tree asymptotically optimal
+func LogNeighbors() NeighborPolicy {
+	return func(n int, dim int) int {
+		if n < 2 {
+			return 1
+		}
+		return int(math.Ceil(math.E * (1 + 1/float64(dim)) * math.Log(float64(n))))
+	}
+}
+
+// Get the number of neighbors considered when rewiring the tree at its
+// current size
+func (path *Path) NeighborCount() int {
+	path.rw.RLock()
+	n := len(path.milestones)
+	path.rw.RUnlock()
+	return path.Neighbors(n, path.Config.Dim())
+}
@@ -5,28 +5,60 @@ import (
 	"image/color"
 	"math"
 	"proj3-redesigned/configspace"
+	"proj3-redesigned/sampling"
+	"proj3-redesigned/steering"
 	"sync"
 
 	"github.com/fogleman/gg"
 )
 
+// Colors used when drawing the path
+var (
+	lightBlue = color.RGBA{R: 173, G: 216, B: 230, A: 255}
+	darkRed   = color.RGBA{R: 139, G: 0, B: 0, A: 255}
+	darkGreen = color.RGBA{R: 0, G: 100, B: 0, A: 255}
+)
+
+// stateDrawer is implemented by collision checkers that can draw the robot
+type stateDrawer interface {
+	Draw(*gg.Context, *configspace.Point)
+}
+
 // Path is the struct that oversees the path planning process
 type Path struct {
-	Config     *configspace.Config // Configuration space
-	Goal       *MileStone          // Goal milestone
-	Start      *MileStone          // Start milestone
-	milestones []*MileStone        // Milestone array of nodes in the tree
-	rw         sync.RWMutex        // Mutex to update milestone array
+	Config     *configspace.Config  // Configuration space
+	ConfigPath string               // File the configuration space was read from
+	Steering   steering.Steering    // Local path between milestones
+	Sampler    sampling.Sampler     // Random states the tree grows towards
+	Neighbors  NeighborPolicy       // Neighbors considered when rewiring
+	Stats      Stats                // Work done while planning
+	Goal       *MileStone           // Goal milestone
+	Start      *MileStone           // Start milestone
+	milestones []*MileStone         // Milestone array of nodes in the tree
+	rw         sync.RWMutex         // Mutex to update milestone array
+	rejected   []*configspace.Point // Samples rejected by collision checks
+	rejectLock sync.Mutex           // Mutex to update rejected samples
 }
 
 // Create a new Path object and set its configuration space
 func NewPath(configPath string) *Path {
+	path := NewPathFromConfig(configspace.NewConfigSpace(configPath))
+	path.ConfigPath = configPath
+	return path
+}
+
+// Create a new Path object for a configuration space, sampling uniformly and
+// rewiring with a fixed number of neighbors
+func NewPathFromConfig(config *configspace.Config) *Path {
 	path := Path{
-		Config:     configspace.NewConfigSpace(configPath),
+		Config:     config,
 		milestones: make([]*MileStone, 0),
 		rw:         sync.RWMutex{},
 	}
 
+	path.Steering = steering.New(path.Config)
+	path.Sampler = sampling.NewUniform(0)
+	path.Neighbors = FixedNeighbors(defaultNeighbors)
 	path.Start = NewMileStone(path.Config.Start)
 	path.Goal = NewMileStone(path.Config.Goal)
 
@@ -44,7 +76,7 @@ func (path *Path) GetNN(ms *MileStone, k int) []*MileStone {
 	// Lock the path's milestone array from writers and process nearest neighbor heap
 	path.rw.RLock()
 	for _, oldMs := range path.milestones {
-		dist := Distance(oldMs.Point, ms.Point)
+		dist := path.Distance(oldMs.Point, ms.Point)
 		neighbor := NewNeighborItem(oldMs, dist)
 		heap.Push(&neighborhood, neighbor)
 	}
@@ -70,30 +102,183 @@ func (path *Path) AddPoint(newMs *MileStone) {
 	path.milestones = append(path.milestones, newMs)
 }
 
+// Record a sample rejected by the collision checks
+func (path *Path) Reject(pt *configspace.Point) {
+	path.rejectLock.Lock()
+	defer path.rejectLock.Unlock()
+	path.rejected = append(path.rejected, pt)
+}
+
+// Get a snapshot of the rejected samples
+func (path *Path) Rejected() []*configspace.Point {
+	path.rejectLock.Lock()
+	defer path.rejectLock.Unlock()
+	return append([]*configspace.Point{}, path.rejected...)
+}
+
 // Get minimum distance to goal
 func (path *Path) DistToGoal() float32 {
 	return path.Goal.Cost
 }
 
+// Get the length of the local path between two points
+func (path *Path) Distance(from *configspace.Point, to *configspace.Point) float32 {
+	return path.Steering.Distance(from, to)
+}
+
+// Check if the local path between two points is not obstructed
+func (path *Path) Visible(from *configspace.Point, to *configspace.Point) bool {
+	return path.Config.PathVisible(path.Steering.Interpolate(from, to))
+}
+
+// Check if the local path from a milestone to a point is not obstructed, with
+// moving obstacles checked at the times the robot traverses the path
+func (path *Path) EdgeVisible(parent *MileStone, to *configspace.Point, dist float32) bool {
+	states := path.Steering.Interpolate(parent.Point, to)
+	if len(path.Config.Moving) == 0 {
+		return path.Config.PathVisible(states)
+	}
+	start := path.ArrivalTime(parent)
+	return path.Config.PathVisibleAt(states, start, start+dist/path.Config.Speed)
+}
+
+// Get the time the robot reaches a milestone, derived from its cost
+func (path *Path) ArrivalTime(ms *MileStone) float32 {
+	return ms.Cost / path.Config.Speed
+}
+
+// Get the points of the solved path from start to goal, nil if the goal has
+// not been reached
+func (path *Path) Waypoints() []*configspace.Point {
+	if path.Goal.Parent == nil {
+		return nil
+	}
+	var waypoints []*configspace.Point
+	for ms := path.Goal; ms != nil; ms = ms.Parent {
+		waypoints = append([]*configspace.Point{ms.Point}, waypoints...)
+	}
+	return waypoints
+}
+
+// Get the length of the solved path, following the local paths between
+// waypoints, zero if the goal has not been reached. Only the position in the
+// workspace is measured, so state coordinates such as velocities are left out,
+// while an arm's path is measured in its joint space
+func (path *Path) Length() float32 {
+	axes := 2
+	if path.Config.Checker != nil {
+		axes = path.Start.Point.Dim()
+	} else if path.Config.WinDepth > 0 {
+		axes = 3
+	}
+	var length float32
+	for ms := path.Goal; ms.Parent != nil; ms = ms.Parent {
+		states := path.Steering.Interpolate(ms.Parent.Point, ms.Point)
+		for i := 1; i < len(states); i++ {
+			length += positionDistance(states[i-1], states[i], axes)
+		}
+	}
+	return length
+}
+
+// Calculate the distance between the first axes coordinates of two points
+func positionDistance(pt1 *configspace.Point, pt2 *configspace.Point, axes int) float32 {
+	var sq float64
+	for axis := 0; axis < axes && axis < pt1.Dim(); axis++ {
+		sq += math.Pow(float64(pt1.Coord(axis)-pt2.Coord(axis)), 2)
+	}
+	return float32(math.Sqrt(sq))
+}
+
 // Draw the path and configuration space
 func (path *Path) Draw(screen *gg.Context) {
+	path.draw(screen, RenderOptions{})
+}
 
-	lightBlue := color.RGBA{R: 173, G: 216, B: 230, A: 255}
-	darkRed := color.RGBA{R: 139, G: 0, B: 0, A: 255}
-	darkGreen := color.RGBA{R: 0, G: 100, B: 0, A: 255}
+// Draw the path and configuration space with the given edge styles
+func (path *Path) draw(screen *gg.Context, opts RenderOptions) {
 
 	// Draw obstacles
 	path.Config.Draw(screen)
 
+	// Joint space trees are not drawn, instead the robot is drawn at each state
+	// of the optimal path when the collision checker knows how
+	if drawer, ok := path.Config.Checker.(stateDrawer); ok && path.Config.Dim() > 2 {
+		screen.SetLineWidth(5.0)
+		screen.SetColor(darkGreen)
+		for _, pt := range path.Waypoints() {
+			drawer.Draw(screen, pt)
+			screen.Stroke()
+		}
+		return
+	}
+
+	path.drawTree(screen, 0, 1, opts)
+}
+
+// Draw the path and configuration space projected onto two coordinate axes
+func (path *Path) DrawProjection(screen *gg.Context, u int, v int) {
+	path.drawProjection(screen, u, v, RenderOptions{})
+}
+
+// Draw the projected path and configuration space with the given edge styles
+func (path *Path) drawProjection(screen *gg.Context, u int, v int, opts RenderOptions) {
+	path.Config.DrawProjection(screen, u, v)
+	path.drawTree(screen, u, v, opts)
+}
+
+// Draw orthographic views of a 3D path. The top view fills the window with
+// the front view below it and the side view to its right, so the screen must
+// be WinWidth+WinDepth wide and WinHeight+WinDepth high
+func (path *Path) DrawViews(screen *gg.Context) {
+	path.drawViews(screen, RenderOptions{})
+}
+
+// Draw orthographic views of a 3D path with the given edge styles
+func (path *Path) drawViews(screen *gg.Context, opts RenderOptions) {
+	width, height := float64(path.Config.WinWidth), float64(path.Config.WinHeight)
+	depth := float64(path.Config.WinDepth)
+
+	// Top view in x and y
+	path.drawProjection(screen, 0, 1, opts)
+
+	// Front view in x and z
+	screen.Push()
+	screen.Translate(0, height)
+	path.drawProjection(screen, 0, 2, opts)
+	screen.Pop()
+
+	// Side view in z and y
+	screen.Push()
+	screen.Translate(width, 0)
+	path.drawProjection(screen, 2, 1, opts)
+	screen.Pop()
+
+	// Separate the views
+	screen.SetLineWidth(2.0)
+	screen.SetColor(color.Gray{Y: 128})
+	screen.DrawLine(0, height, width+depth, height)
+	screen.DrawLine(width, 0, width, height+depth)
+	screen.Stroke()
+}
+
+// Draw the tree, optimal path, start and goal projected onto two axes
+func (path *Path) drawTree(screen *gg.Context, u int, v int, opts RenderOptions) {
+	lineWidth := opts.lineWidth()
+	maxCost := path.maxCost()
+
 	// Draw path tree
 	var treeDraw func(*MileStone)
 	treeDraw = func(lastPt *MileStone) {
 		lastPt.Children.Range(func(key, value any) bool {
 			child := value.(*MileStone)
-			screen.SetLineWidth(5.0)
-			screen.SetColor(lightBlue)
-			screen.DrawLine(float64(lastPt.Point.X), float64(lastPt.Point.Y),
-				float64(child.Point.X), float64(child.Point.Y))
+			screen.SetLineWidth(lineWidth)
+			if opts.CostColors {
+				screen.SetColor(costColor(child.Cost / maxCost))
+			} else {
+				screen.SetColor(lightBlue)
+			}
+			path.drawEdge(screen, lastPt, child, u, v)
 			screen.Stroke()
 			treeDraw(child)
 			return true
@@ -103,28 +288,52 @@ func (path *Path) Draw(screen *gg.Context) {
 
 	// Draw optimal path and Start point
 	screen.SetColor(darkGreen)
-	screen.DrawPoint(float64(path.Start.Point.X), float64(path.Start.Point.Y), 5.0)
+	screen.DrawPoint(float64(path.Start.Point.Coord(u)), float64(path.Start.Point.Coord(v)), 5.0)
 	screen.Fill()
 	ms := path.Goal
 	for ms.Parent != nil {
-		screen.SetLineWidth(6.0)
+		screen.SetLineWidth(lineWidth + 1)
 		screen.SetColor(darkGreen)
-		screen.DrawLine(float64(ms.Point.X), float64(ms.Point.Y),
-			float64(ms.Parent.Point.X), float64(ms.Parent.Point.Y))
+		path.drawEdge(screen, ms.Parent, ms, u, v)
 		screen.Stroke()
 		ms = ms.Parent
 	}
 
+	// Draw the robot's footprint at each state of the optimal path
+	if path.Config.Robot != nil && u == 0 && v == 1 {
+		screen.SetLineWidth(3.0)
+		screen.SetColor(darkGreen)
+		for _, pt := range path.Waypoints() {
+			path.Config.Robot.Draw(screen, pt)
+			screen.Stroke()
+		}
+	}
+
 	// Draw Start and Goal points
 	screen.SetColor(darkRed)
-	screen.DrawPoint(float64(path.Goal.Point.X), float64(path.Goal.Point.Y), 5.0)
+	screen.DrawPoint(float64(path.Goal.Point.Coord(u)), float64(path.Goal.Point.Coord(v)), 5.0)
 	screen.Fill()
 }
 
+// Trace the local path from a parent milestone to its child projected onto two
+// axes
+func (path *Path) drawEdge(screen *gg.Context, parent *MileStone, child *MileStone,
+	u int, v int,
+) {
+	states := path.Steering.Interpolate(parent.Point, child.Point)
+	screen.MoveTo(float64(states[0].Coord(u)), float64(states[0].Coord(v)))
+	for _, pt := range states[1:] {
+		screen.LineTo(float64(pt.Coord(u)), float64(pt.Coord(v)))
+	}
+}
+
 // Calculate the distance between two points in the configuration space
 func Distance(pt1 *configspace.Point, pt2 *configspace.Point) float32 {
 	base_sq := math.Pow(float64(pt1.X-pt2.X), 2)
 	height_sq := math.Pow(float64(pt1.Y-pt2.Y), 2)
+	for i := range pt1.Q {
+		height_sq += math.Pow(float64(pt1.Q[i]-pt2.Q[i]), 2)
+	}
 
 	return float32(math.Sqrt(base_sq + height_sq))
 }
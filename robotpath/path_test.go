@@ -0,0 +1,56 @@
+package robotpath
+
+// Unit testing for path.go. Tests the following functions:
+// Length
+//
+// Benchmarks the following functions:
+// GetNN
+//
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"proj3-redesigned/configspace"
+	"testing"
+)
+
+// Test a kinodynamic path's length leaves out the velocities
+func TestLength(t *testing.T) {
+	path := NewPath("../data/kinodynamicExample.txt")
+	mid := NewMileStone(&configspace.Point{X: 500, Y: 100, Q: []float32{0, 0}})
+	mid.SetParent(path.Start, 0, 1)
+	path.Goal.SetParent(mid, 0, 1)
+	// Between states at rest the local paths are straight
+	want := 400 + 400*math.Sqrt(5)
+	if length := path.Length(); math.Abs(float64(length)-want) > 0.5 {
+		t.Errorf("Expected a length of %f, got %f", want, length)
+	}
+}
+
+// Benchmark GetNN for the rewiring neighborhood in trees of increasing size
+func BenchmarkGetNN(b *testing.B) {
+	for _, size := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			path := NewPath("../data/easyMaze.txt")
+			rng := rand.New(rand.NewSource(1))
+			randomPoint := func() *configspace.Point {
+				return &configspace.Point{
+					X: rng.Float32() * path.Config.WinWidth,
+					Y: rng.Float32() * path.Config.WinHeight,
+				}
+			}
+			for i := 1; i < size; i++ {
+				path.AddPoint(NewMileStone(randomPoint()))
+			}
+			queries := make([]*MileStone, 256)
+			for i := range queries {
+				queries[i] = NewMileStone(randomPoint())
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				path.GetNN(queries[i%len(queries)], defaultNeighbors)
+			}
+		})
+	}
+}
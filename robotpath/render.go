@@ -0,0 +1,222 @@
+package robotpath
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"proj3-redesigned/configspace"
+	"time"
+
+	"github.com/fogleman/gg"
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// Width of tree edges when none is given
+const defaultLineWidth = 5.0
+
+// Number of heatmap cells along the window's longer side
+const heatmapCells = 64
+
+// Stops of the viridis colormap used to color edges by cost
+var colormap = []color.RGBA{
+	{R: 68, G: 1, B: 84, A: 255},
+	{R: 59, G: 82, B: 139, A: 255},
+	{R: 33, G: 145, B: 140, A: 255},
+	{R: 94, G: 201, B: 98, A: 255},
+	{R: 253, G: 231, B: 37, A: 255},
+}
+
+// RenderOptions control how a path is rendered, the zero value renders at full
+// size with the tree in light blue as Draw does
+type RenderOptions struct {
+	Scale      float64       // Output size relative to the window, 1 when zero
+	LineWidth  float64       // Width of tree edges, the solution is one wider
+	CostColors bool          // Color tree edges by cost-to-come
+	Heatmap    bool          // Overlay the density of drawn samples
+	Rejected   bool          // Mark samples rejected by collision checks
+	Legend     bool          // Show the goal distance, sample count and runtime
+	Samples    int           // Samples shown in the legend, counted when zero
+	Runtime    time.Duration // Planning time shown in the legend
+}
+
+// Get the width of tree edges
+func (opts RenderOptions) lineWidth() float64 {
+	if opts.LineWidth <= 0 {
+		return defaultLineWidth
+	}
+	return opts.LineWidth
+}
+
+// Render the path onto a white image, 3D workspaces are shown as orthographic
+// views with overlays on the top view
+func (path *Path) Render(opts RenderOptions) *image.RGBA {
+	scale := opts.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	width, height := float64(path.Config.WinWidth), float64(path.Config.WinHeight)
+	is3D := path.Config.WinDepth > 0 && path.Config.Checker == nil
+	if is3D {
+		width += float64(path.Config.WinDepth)
+		height += float64(path.Config.WinDepth)
+	}
+	img := image.NewRGBA(image.Rect(0, 0, int(width*scale), int(height*scale)))
+	screen := gg.NewContextForRGBA(img)
+	screen.SetColor(color.White)
+	screen.Clear()
+	screen.Scale(scale, scale)
+
+	if is3D {
+		path.drawViews(screen, opts)
+	} else {
+		path.draw(screen, opts)
+	}
+	if opts.Heatmap {
+		path.drawHeatmap(screen)
+	}
+	if opts.Rejected {
+		path.drawRejected(screen, opts.lineWidth()/2)
+	}
+
+	// The legend is sized for the output rather than the window
+	if opts.Legend {
+		screen.Identity()
+		path.drawLegend(screen, opts)
+	}
+	return img
+}
+
+// Get the largest cost in the tree, used to normalize edge colors
+func (path *Path) maxCost() float32 {
+	var maxCost float32
+	for _, ms := range path.MileStones() {
+		if ms.Cost > maxCost {
+			maxCost = ms.Cost
+		}
+	}
+	if maxCost == 0 {
+		return 1
+	}
+	return maxCost
+}
+
+// Get the colormap's color for a value between 0 and 1
+func costColor(value float32) color.RGBA {
+	t := math.Max(0, math.Min(1, float64(value))) * float64(len(colormap)-1)
+	i := int(math.Min(t, float64(len(colormap)-2)))
+	frac := t - float64(i)
+	lerp := func(a, b uint8) uint8 {
+		return uint8(float64(a) + frac*(float64(b)-float64(a)) + 0.5)
+	}
+	c1, c2 := colormap[i], colormap[i+1]
+	return color.RGBA{R: lerp(c1.R, c2.R), G: lerp(c1.G, c2.G), B: lerp(c1.B, c2.B), A: 255}
+}
+
+// Overlay the number of samples drawn in each cell of a grid over the window,
+// counting both milestones and rejected samples
+func (path *Path) drawHeatmap(screen *gg.Context) {
+	width, height := float64(path.Config.WinWidth), float64(path.Config.WinHeight)
+	cell := math.Max(width, height) / heatmapCells
+	cols, rows := int(math.Ceil(width/cell)), int(math.Ceil(height/cell))
+	counts := make([]int, cols*rows)
+
+	add := func(pt *configspace.Point) {
+		col, row := int(float64(pt.X)/cell), int(float64(pt.Y)/cell)
+		if col >= 0 && col < cols && row >= 0 && row < rows {
+			counts[row*cols+col]++
+		}
+	}
+	for _, ms := range path.MileStones() {
+		add(ms.Point)
+	}
+	for _, pt := range path.Rejected() {
+		add(pt)
+	}
+	maxCount := 0
+	for _, count := range counts {
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+
+	for i, count := range counts {
+		if count == 0 {
+			continue
+		}
+		alpha := uint8(40 + 160*float64(count)/float64(maxCount))
+		screen.SetColor(color.NRGBA{R: 255, G: 69, B: 0, A: alpha})
+		screen.DrawRectangle(float64(i%cols)*cell, float64(i/cols)*cell, cell, cell)
+		screen.Fill()
+	}
+}
+
+// Mark rejected samples with crosses
+func (path *Path) drawRejected(screen *gg.Context, size float64) {
+	screen.SetLineWidth(size / 2)
+	screen.SetColor(darkRed)
+	for _, pt := range path.Rejected() {
+		x, y := float64(pt.X), float64(pt.Y)
+		screen.DrawLine(x-size, y-size, x+size, y+size)
+		screen.DrawLine(x-size, y+size, x+size, y-size)
+	}
+	screen.Stroke()
+}
+
+// Draw a legend with the goal distance, sample count and runtime in the top
+// left corner, along with the colormap when edges are colored by cost
+func (path *Path) drawLegend(screen *gg.Context, opts RenderOptions) {
+	size := math.Max(13, float64(screen.Width())/60)
+	font, _ := truetype.Parse(goregular.TTF)
+	screen.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: size}))
+
+	samples := opts.Samples
+	if samples == 0 {
+		samples = len(path.MileStones()) + len(path.Rejected()) - 1
+	}
+	goal := "not reached"
+	if path.Goal.Parent != nil {
+		goal = fmt.Sprintf("%.1f", path.DistToGoal())
+	}
+	lines := []string{
+		"Goal distance: " + goal,
+		fmt.Sprintf("Samples: %d", samples),
+		fmt.Sprintf("Runtime: %.2fs", opts.Runtime.Seconds()),
+	}
+	if opts.CostColors {
+		lines = append(lines, fmt.Sprintf("Cost: 0 to %.1f", path.maxCost()))
+	}
+
+	var textWidth float64
+	for _, line := range lines {
+		if w, _ := screen.MeasureString(line); w > textWidth {
+			textWidth = w
+		}
+	}
+	lineHeight := size * 1.4
+	margin := size / 2
+	boxHeight := lineHeight*float64(len(lines)) + 2*margin
+	if opts.CostColors {
+		boxHeight += lineHeight
+	}
+
+	screen.SetColor(color.NRGBA{R: 255, G: 255, B: 255, A: 220})
+	screen.DrawRectangle(0, 0, textWidth+2*margin, boxHeight)
+	screen.Fill()
+	screen.SetColor(color.Black)
+	for i, line := range lines {
+		screen.DrawString(line, margin, margin+lineHeight*float64(i)+size)
+	}
+
+	// Colorbar below the text
+	if opts.CostColors {
+		top := margin + lineHeight*float64(len(lines)) + size*0.2
+		steps := int(textWidth)
+		for i := 0; i < steps; i++ {
+			screen.SetColor(costColor(float32(i) / float32(steps)))
+			screen.DrawRectangle(margin+float64(i), top, 1, size)
+			screen.Fill()
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package robotpath
+
+// Unit testing for render.go. Tests the following functions:
+// costColor
+// Render
+//
+
+import (
+	"image/color"
+	"proj3-redesigned/configspace"
+	"testing"
+)
+
+// Test the colormap's ends and clamping
+func TestCostColor(t *testing.T) {
+	if costColor(0) != colormap[0] || costColor(-1) != colormap[0] {
+		t.Errorf("expected %v at zero, got %v", colormap[0], costColor(0))
+	}
+	last := colormap[len(colormap)-1]
+	if costColor(1) != last || costColor(2) != last {
+		t.Errorf("expected %v at one, got %v", last, costColor(1))
+	}
+	if costColor(0.25) != colormap[1] {
+		t.Errorf("expected %v at a stop, got %v", colormap[1], costColor(0.25))
+	}
+}
+
+// Test rendering is scaled and draws the requested overlays
+func TestRender(t *testing.T) {
+	path := testPath(t)
+	if img := path.Render(RenderOptions{}); img.Bounds().Dx() != 100 {
+		t.Errorf("expected a 100 pixel image, got %v", img.Bounds())
+	}
+
+	path.Reject(&configspace.Point{X: 80, Y: 10})
+	if len(path.Rejected()) != 1 {
+		t.Errorf("expected a rejected sample, got %d", len(path.Rejected()))
+	}
+	img := path.Render(RenderOptions{Scale: 2, Rejected: true, CostColors: true})
+	if img.Bounds().Dx() != 200 {
+		t.Errorf("expected a 200 pixel image, got %v", img.Bounds())
+	}
+	if c := img.RGBAAt(160, 20); c != darkRed {
+		t.Errorf("expected a rejected mark, got %v", c)
+	}
+
+	// Edge from c's parent a at (30, 30) to c at (30, 60) has cost 58 of 85
+	expected := costColor(58.0 / 85)
+	if c := img.RGBAAt(60, 100); c != expected {
+		t.Errorf("expected edge color %v, got %v", expected, c)
+	}
+	if c := img.RGBAAt(1, 199); c != (color.RGBA{R: 255, G: 255, B: 255, A: 255}) {
+		t.Errorf("expected a white background, got %v", c)
+	}
+}
@@ -0,0 +1,54 @@
+package robotpath
+
+// Get a snapshot of the milestones in the tree
+func (path *Path) MileStones() []*MileStone {
+	path.rw.RLock()
+	defer path.rw.RUnlock()
+	return append([]*MileStone{}, path.milestones...)
+}
+
+// Remove milestones from the path, they should already be detached from the
+// tree
+func (path *Path) Remove(removed map[*MileStone]bool) {
+	path.rw.Lock()
+	defer path.rw.Unlock()
+	kept := path.milestones[:0]
+	for _, ms := range path.milestones {
+		if !removed[ms] {
+			kept = append(kept, ms)
+		}
+	}
+	path.milestones = kept
+}
+
+// Make a milestone the root of the tree. The milestone must already be
+// attached, the edges between it and the old root are reversed and every cost
+// is recomputed. Returns the milestones whose edge to their parent was reversed
+func (path *Path) Reroot(root *MileStone) []*MileStone {
+	// Collect the chain from the new root up to the old one
+	var chain []*MileStone
+	for ms := root; ms != nil; ms = ms.Parent {
+		chain = append(chain, ms)
+	}
+
+	// Reverse each edge of the chain, starting from the old root
+	for i := len(chain) - 1; i > 0; i-- {
+		parent, child := chain[i], chain[i-1]
+		parent.costLock.Lock()
+		parent.removeChild(child)
+		child.setChild(parent)
+		parent.Parent = child
+		parent.dist = path.Distance(child.Point, parent.Point)
+		parent.costLock.Unlock()
+	}
+
+	root.costLock.Lock()
+	root.Parent = nil
+	root.dist = 0
+	root.Cost = 0
+	root.costLock.Unlock()
+
+	path.Start = root
+	root.UpdateChildrenCost()
+	return chain[1:]
+}
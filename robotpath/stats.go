@@ -0,0 +1,16 @@
+package robotpath
+
+import (
+	"sync/atomic"
+)
+
+// Stats count the work done on a path, safe to update from concurrent tasks.
+// Phase times are summed over every task, so parallel runs can exceed the
+// wall time
+type Stats struct {
+	Rewires    atomic.Int64 // Parents changed by rewiring, including the goal's
+	Retries    atomic.Int64 // SetParent attempts invalidated by a concurrent cost change
+	SampleTime atomic.Int64 // Nanoseconds spent sampling and extending the tree
+	RewireTime atomic.Int64 // Nanoseconds spent rewiring
+	CostTime   atomic.Int64 // Nanoseconds spent propagating costs to descendants
+}
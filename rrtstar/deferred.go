@@ -0,0 +1,117 @@
+package rrtstar
+
+import (
+	"proj3-redesigned/configspace"
+	"proj3-redesigned/robotpath"
+	"proj3-redesigned/sampling"
+	"time"
+)
+
+// plan is a milestone and its rewires found against the tree as it was when
+// the plan was made, with the collision checks already done
+type plan struct {
+	rejected []*configspace.Point // Samples rejected before the milestone was found
+	parent   *robotpath.MileStone // Nearest milestone the new one extends from
+	dist     float32              // Length of the local path from the parent
+	rewires  []rewire             // Visible edges that lowered a cost, in the order found
+}
+
+// rewire is an unobstructed edge that gives child a cheaper path through parent
+type rewire struct {
+	child  *robotpath.MileStone
+	parent *robotpath.MileStone
+	dist   float32
+}
+
+// Create a PathUpdate whose Run only plans a milestone without changing the
+// path, leaving Commit to add it. Updates run side by side then see the same
+// tree, and committing them in a fixed order gives the same path whatever
+// order they ran in. The sampler draws the update's samples
+func NewDeferredUpdate(path *robotpath.Path, sampler sampling.Sampler) *PathUpdate {
+	return &PathUpdate{
+		path:    path,
+		sampler: sampler,
+	}
+}
+
+// Plan a milestone against the current tree, reading it without changes
+func (task *PathUpdate) planMileStone() {
+	path := task.path
+	start := time.Now()
+	p := &plan{}
+	bounds := path.Config.Bounds()
+	var ms *robotpath.MileStone
+	for ms == nil {
+		ms = robotpath.NewMileStone(task.sampler.Sample(bounds))
+		p.parent = path.GetNN(ms, 1)[0]
+		p.dist = extend(ms, p.parent, path)
+		if !path.Config.InBounds(ms.Point) || !path.EdgeVisible(p.parent, ms.Point, p.dist) {
+			p.rejected = append(p.rejected, ms.Point)
+			ms = nil
+		}
+	}
+	// The milestone is not in the tree yet, its cost is only an estimate for
+	// finding rewires
+	ms.Cost = p.parent.Cost + p.dist
+	sampled := time.Now()
+	path.Stats.SampleTime.Add(int64(sampled.Sub(start)))
+
+	if path.Steering.Exact() {
+		timed := len(path.Config.Moving) > 0
+		for _, n := range path.GetNN(ms, path.NeighborCount()) {
+			distFromNew := path.Distance(ms.Point, n.Point)
+			distFromNeighbor := path.Distance(n.Point, ms.Point)
+			if ms.Cost+distFromNew < n.Cost && !timed {
+				if path.EdgeVisible(ms, n.Point, distFromNew) {
+					p.rewires = append(p.rewires, rewire{n, ms, distFromNew})
+				}
+			} else if n.Cost+distFromNeighbor < ms.Cost {
+				if path.EdgeVisible(n, ms.Point, distFromNeighbor) {
+					p.rewires = append(p.rewires, rewire{ms, n, distFromNeighbor})
+					ms.Cost = n.Cost + distFromNeighbor
+				}
+			}
+		}
+	}
+
+	// Connect to the goal if the milestone is a cheaper way there
+	distToGoal := path.Distance(ms.Point, path.Goal.Point)
+	goalCost := path.Goal.Cost
+	if nearGoal(path, ms.Point, distToGoal) && (goalCost == 0.0 || ms.Cost+distToGoal < goalCost) &&
+		path.EdgeVisible(ms, path.Goal.Point, distToGoal) {
+		p.rewires = append(p.rewires, rewire{path.Goal, ms, distToGoal})
+	}
+	path.Stats.RewireTime.Add(int64(time.Since(sampled)))
+
+	task.mileStone = ms
+	task.plan = p
+}
+
+// Commit adds a planned milestone to the path. Each rewire is applied if it
+// still lowers the child's cost after the updates committed before it, so
+// commits must not run concurrently
+func (task *PathUpdate) Commit() {
+	p := task.plan
+	if p == nil {
+		return
+	}
+	path := task.path
+	start := time.Now()
+	for _, pt := range p.rejected {
+		path.Reject(pt)
+	}
+
+	ms := task.mileStone
+	ms.SetParent(p.parent, ms.Cost, p.dist)
+	path.AddPoint(ms)
+	for _, rw := range p.rewires {
+		unsolvedGoal := rw.child == path.Goal && path.Goal.Parent == nil
+		if unsolvedGoal || rw.parent.Cost+rw.dist < rw.child.Cost {
+			rw.child.SetParent(rw.parent, rw.child.Cost, rw.dist)
+			rw.child.UpdateChildrenCost()
+			path.Stats.Rewires.Add(1)
+		}
+	}
+	task.plan = nil
+	path.Stats.CostTime.Add(int64(time.Since(start)))
+}
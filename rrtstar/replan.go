@@ -0,0 +1,149 @@
+// Online replanning adapted from Otte and Frazzoli, "RRTX: Asymptotically
+// Optimal Single-Query Sampling-Based Motion Planning with Quick Replanning"
+
+package rrtstar
+
+import (
+	"errors"
+	"proj3-redesigned/configspace"
+	"proj3-redesigned/robotpath"
+)
+
+// Number of neighbors considered when reconnecting a milestone
+const repairNeighbors = 20
+
+// AddObstacle adds an obstacle to a live path. Milestones whose local path from
+// their parent is now obstructed are orphaned along with their subtrees, then
+// reconnected to the rest of the tree where possible. Returns the number of
+// milestones dropped because they could not be reconnected. Must not be called
+// while updates are running on the path
+func AddObstacle(path *robotpath.Path, o configspace.Obstacle) int {
+	path.Config.AddObstacle(o)
+	return repair(path)
+}
+
+// RemoveObstacle removes an obstacle from a live path and rewires every
+// milestone so the tree can take shortcuts through the freed space. Returns
+// false if the obstacle was not found
+func RemoveObstacle(path *robotpath.Path, o configspace.Obstacle) bool {
+	if !path.Config.RemoveObstacle(o) {
+		return false
+	}
+	for _, ms := range path.MileStones() {
+		Rewire(ms, path, true)
+	}
+	return true
+}
+
+// MoveStart re-roots the tree at the robot's current position. The new start
+// is connected to its nearest visible milestone, the edges leading back to the
+// old start are reversed, and milestones that the reversed edges no longer
+// reach are repaired as in AddObstacle. Returns the number of dropped
+// milestones
+func MoveStart(path *robotpath.Path, pt *configspace.Point) (int, error) {
+	if !path.Steering.Exact() {
+		return 0, errors.New("moving the start requires exact steering")
+	}
+	if len(path.Config.Moving) > 0 {
+		return 0, errors.New("moving the start is not supported with moving obstacles")
+	}
+
+	start := robotpath.NewMileStone(pt)
+	var nearest *robotpath.MileStone
+	for _, n := range path.GetNN(start, repairNeighbors) {
+		if path.Visible(pt, n.Point) {
+			nearest = n
+			break
+		}
+	}
+	if nearest == nil {
+		return 0, errors.New("no milestone is visible from the new start")
+	}
+
+	// Hang the new start off its neighbor, then flip the tree around it
+	start.SetParent(nearest, start.Cost, path.Distance(nearest.Point, pt))
+	path.AddPoint(start)
+	path.Reroot(start)
+	path.Config.Start = pt
+
+	dropped := repair(path)
+	Rewire(start, path, true)
+	return dropped, nil
+}
+
+// Orphan every milestone whose edge to its parent is obstructed along with its
+// subtree, then reconnect orphans until no more can be reached. Orphans left
+// over are removed from the path, an orphaned goal is left unsolved
+func repair(path *robotpath.Path) int {
+	milestones := append(path.MileStones(), path.Goal)
+
+	orphans := make(map[*robotpath.MileStone]bool)
+	var orphan func(*robotpath.MileStone)
+	orphan = func(ms *robotpath.MileStone) {
+		orphans[ms] = true
+		ms.Children.Range(func(key, value any) bool {
+			orphan(value.(*robotpath.MileStone))
+			return true
+		})
+	}
+	for _, ms := range milestones {
+		if ms.Parent != nil && !orphans[ms] && !path.EdgeVisible(ms.Parent, ms.Point, ms.Dist()) {
+			orphan(ms)
+		}
+	}
+
+	// Orphans are detached individually so each can find its own best parent
+	var pending []*robotpath.MileStone
+	for _, ms := range milestones {
+		if orphans[ms] {
+			ms.Detach()
+			pending = append(pending, ms)
+		}
+	}
+
+	// Reconnected orphans can adopt others, so repeat until nothing changes
+	for progress := true; progress; {
+		progress = false
+		remaining := pending[:0]
+		for _, ms := range pending {
+			if reconnect(ms, path, orphans) {
+				delete(orphans, ms)
+				progress = true
+			} else {
+				remaining = append(remaining, ms)
+			}
+		}
+		pending = remaining
+	}
+
+	delete(orphans, path.Goal)
+	path.Remove(orphans)
+	return len(orphans)
+}
+
+// Connect an orphaned milestone to the cheapest visible neighbor still in the
+// tree. Without exact steering only the goal can be reconnected
+func reconnect(ms *robotpath.MileStone, path *robotpath.Path,
+	orphans map[*robotpath.MileStone]bool,
+) bool {
+	isGoal := ms == path.Goal
+	if !isGoal && !path.Steering.Exact() {
+		return false
+	}
+
+	var best *robotpath.MileStone
+	var bestCost, bestDist float32
+	for _, n := range path.GetNN(ms, repairNeighbors) {
+		if orphans[n] {
+			continue
+		}
+		dist := path.Distance(n.Point, ms.Point)
+		if isGoal && !nearGoal(path, n.Point, dist) {
+			continue
+		}
+		if (best == nil || n.Cost+dist < bestCost) && path.EdgeVisible(n, ms.Point, dist) {
+			best, bestCost, bestDist = n, n.Cost+dist, dist
+		}
+	}
+	return best != nil && ms.SetParent(best, ms.Cost, bestDist)
+}
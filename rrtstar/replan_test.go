@@ -0,0 +1,113 @@
+package rrtstar
+
+// Unit testing for replan.go. Tests the following functions:
+// AddObstacle
+// RemoveObstacle
+// MoveStart
+//
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"proj3-redesigned/configspace"
+	"proj3-redesigned/robotpath"
+	"testing"
+)
+
+// Build a path in an open window solved with the given number of samples
+func solvedPath(t *testing.T, samples int) *robotpath.Path {
+	configPath := filepath.Join(t.TempDir(), "open.txt")
+	config := "window,500,500\nvisibility,60\nstart,20,20\ngoal,480,480\n"
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := robotpath.NewPath(configPath)
+	for i := 0; i < samples; i++ {
+		NewUpdate(path, true).Run()
+	}
+	if path.Goal.Parent == nil {
+		t.Fatal("path was not solved")
+	}
+	return path
+}
+
+// Check every milestone is reachable from the start through unobstructed edges
+// with consistent costs
+func checkTree(t *testing.T, path *robotpath.Path) {
+	inTree := make(map[*robotpath.MileStone]bool)
+	for _, ms := range path.MileStones() {
+		inTree[ms] = true
+	}
+	for _, ms := range append(path.MileStones(), path.Goal) {
+		if ms == path.Start || (ms == path.Goal && ms.Parent == nil) {
+			continue
+		}
+		if ms.Parent == nil || !inTree[ms.Parent] {
+			t.Errorf("milestone at %+v is not connected to the tree", *ms.Point)
+		} else if !path.Visible(ms.Parent.Point, ms.Point) {
+			t.Errorf("edge to %+v is obstructed", *ms.Point)
+		} else if math.Abs(float64(ms.Cost-ms.Parent.Cost-ms.Dist())) > 1e-2 {
+			t.Errorf("milestone at %+v has cost %v, parent %v + %v",
+				*ms.Point, ms.Cost, ms.Parent.Cost, ms.Dist())
+		}
+	}
+}
+
+// Test adding an obstacle across the solution keeps the tree valid, and
+// removing it lets the solution recover
+func TestAddRemoveObstacle(t *testing.T) {
+	path := solvedPath(t, 2000)
+	before := len(path.MileStones())
+
+	// Wall across the diagonal with a gap on the right
+	wall := configspace.NewRectangleObstacle([]string{"0", "240", "20", "420"})
+	dropped := AddObstacle(path, wall)
+	checkTree(t, path)
+	if len(path.MileStones()) != before-dropped {
+		t.Errorf("expected %d milestones, got %d", before-dropped, len(path.MileStones()))
+	}
+	if dropped > before/2 {
+		t.Errorf("dropped %d of %d milestones", dropped, before)
+	}
+
+	for i := 0; i < 2000; i++ {
+		NewUpdate(path, true).Run()
+	}
+	checkTree(t, path)
+	if path.Goal.Parent == nil {
+		t.Fatal("path was not solved around the wall")
+	}
+	blocked := path.Goal.Cost
+
+	if !RemoveObstacle(path, wall) {
+		t.Fatal("wall was not removed")
+	}
+	if RemoveObstacle(path, wall) {
+		t.Error("wall was removed twice")
+	}
+	checkTree(t, path)
+	if path.Goal.Cost > blocked {
+		t.Errorf("goal cost rose from %v to %v after removing the wall", blocked, path.Goal.Cost)
+	}
+}
+
+// Test moving the start along the solution re-roots the tree there
+func TestMoveStart(t *testing.T) {
+	path := solvedPath(t, 2000)
+	waypoints := path.Waypoints()
+	pt := waypoints[len(waypoints)/2]
+	robot := &configspace.Point{X: pt.X + 1, Y: pt.Y + 1}
+	before := path.Goal.Cost
+
+	if _, err := MoveStart(path, robot); err != nil {
+		t.Fatal(err)
+	}
+	checkTree(t, path)
+	if path.Start.Point != robot || path.Start.Parent != nil || path.Start.Cost != 0 {
+		t.Errorf("start was not moved to %+v", *robot)
+	}
+	if path.Goal.Parent == nil || path.Goal.Cost >= before {
+		t.Errorf("expected goal cost below %v, got %v", before, path.Goal.Cost)
+	}
+}
@@ -1,23 +1,29 @@
 package rrtstar
 
 import (
+	"math"
+	"proj3-redesigned/configspace"
 	"proj3-redesigned/robotpath"
 )
 
 // Rewiring of the RRT* algorithm, assumes milestone that is passed is randomly
-// drawn and valid w.r.t. obstacles in the configuration space, see SamplePoint()
+// drawn and valid w.r.t. obstacles in the configuration space, see SamplePoint().
+// Steering methods that cannot connect arbitrary states grow the tree without
+// rewiring, as in RRT
 func Rewire(ms *robotpath.MileStone, path *robotpath.Path, doCostUpdate bool) {
 	// Rewire the tree to account for the new milestone
-	rewirePath(ms, path)
+	exact := path.Steering.Exact()
+	if exact {
+		rewirePath(ms, path)
+	}
 
 	// Check if milestone is most optimal path to goal
 	checkSuccess := false
 	for !checkSuccess {
-		distBetweenGoal := robotpath.Distance(ms.Point, path.Goal.Point)
+		distBetweenGoal := path.Distance(ms.Point, path.Goal.Point)
 		goalCost := path.Goal.Cost
 
-		if distBetweenGoal < path.Config.Visibility &&
-			(goalCost == 0.0 || ms.Cost+distBetweenGoal < goalCost) {
+		if nearGoal(path, ms.Point, distBetweenGoal) && (goalCost == 0.0 || ms.Cost+distBetweenGoal < goalCost) {
 			checkSuccess = tryRewire(path.Goal, ms, goalCost, distBetweenGoal, path)
 
 		} else {
@@ -31,29 +37,45 @@ func Rewire(ms *robotpath.MileStone, path *robotpath.Path, doCostUpdate bool) {
 	}
 }
 
-// Rewire the tree to account for the new MileStone
+// Check if a point at the given distance from the goal can connect to it.
+// Without exact steering the goal is a region around its position
+func nearGoal(path *robotpath.Path, pt *configspace.Point, dist float32) bool {
+	if path.Steering.Exact() {
+		return dist < path.Config.Visibility
+	}
+	return float32(math.Hypot(float64(pt.X-path.Goal.Point.X),
+		float64(pt.Y-path.Goal.Point.Y))) < path.Config.Visibility
+}
+
+// Rewire the tree to account for the new MileStone. With moving obstacles
+// only the new milestone's parent is improved, since re-parenting an existing
+// milestone would shift the times its subtree was collision checked at
 func rewirePath(ms *robotpath.MileStone, path *robotpath.Path) {
-	// Find 10 nearest neighbors in the path
-	nHood := path.GetNN(ms, 10)
+	timed := len(path.Config.Moving) > 0
+
+	// Find the nearest neighbors in the path
+	nHood := path.GetNN(ms, path.NeighborCount())
 
 	// Check if each neighbor requires re-wireing
 	for _, n := range nHood {
 		checkSuccess := false
 
 		for !checkSuccess {
-			// Determine relative distances between points
-			distBetween := robotpath.Distance(ms.Point, n.Point)
+			// Determine relative distances between points, the local path may
+			// differ in each direction for car-like robots
+			distFromNew := path.Distance(ms.Point, n.Point)
+			distFromNeighbor := path.Distance(n.Point, ms.Point)
 			msCost, neighborCost := ms.Cost, n.Cost
-			distThroughNew := msCost + distBetween
-			distToNew := neighborCost + distBetween
+			distThroughNew := msCost + distFromNew
+			distToNew := neighborCost + distFromNeighbor
 
-			if distThroughNew < neighborCost {
+			if distThroughNew < neighborCost && !timed {
 				// Shorter path from new milestone to neighbor
-				checkSuccess = tryRewire(n, ms, neighborCost, distBetween, path)
+				checkSuccess = tryRewire(n, ms, neighborCost, distFromNew, path)
 
 			} else if distToNew < msCost {
 				// Shorter path from neighbor to new milestone
-				checkSuccess = tryRewire(ms, n, msCost, distBetween, path)
+				checkSuccess = tryRewire(ms, n, msCost, distFromNeighbor, path)
 
 			} else {
 				// No re-wireing needed
@@ -68,12 +90,14 @@ func rewirePath(ms *robotpath.MileStone, path *robotpath.Path) {
 func tryRewire(newChild *robotpath.MileStone, newParent *robotpath.MileStone,
 	childCost float32, dist float32, path *robotpath.Path,
 ) bool {
-	// Check if points visible to another
-	if path.Config.Visible(newChild.Point, newParent.Point) {
+	// Check if the local path between the points is unobstructed
+	if path.EdgeVisible(newParent, newChild.Point, dist) {
 		// Attempt to set new parent
 		if !newChild.SetParent(newParent, childCost, dist) {
+			path.Stats.Retries.Add(1)
 			return false
 		}
+		path.Stats.Rewires.Add(1)
 	}
 	return true
 }
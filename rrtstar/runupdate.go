@@ -5,6 +5,8 @@ package rrtstar
 
 import (
 	"proj3-redesigned/robotpath"
+	"proj3-redesigned/sampling"
+	"time"
 )
 
 // PathUpdateTask updates the path through the task of adding a milestone
@@ -12,7 +14,8 @@ type PathUpdate struct {
 	path       *robotpath.Path
 	mileStone  *robotpath.MileStone
 	updateCost bool
-	Done       chan any
+	sampler    sampling.Sampler // Sampler of a deferred update, nil otherwise
+	plan       *plan            // Planned milestone waiting for Commit
 }
 
 // Create a new PathUpdateTask
@@ -21,15 +24,36 @@ func NewUpdate(path *robotpath.Path, updateCostInternally bool) *PathUpdate {
 		path:       path,
 		mileStone:  nil,
 		updateCost: updateCostInternally,
-		Done:       make(chan any),
 	}
 }
 
-// Run update according to the RRT* algorithm
+// Run update according to the RRT* algorithm, timing each phase. A deferred
+// update only plans its milestone, see Commit
 func (task *PathUpdate) Run() {
-	defer close(task.Done)
+	if task.sampler != nil {
+		task.planMileStone()
+		return
+	}
+	start := time.Now()
 	task.mileStone = SamplePoint(task.path)
-	Rewire(task.mileStone, task.path, task.updateCost)
+	sampled := time.Now()
+	task.path.Stats.SampleTime.Add(int64(sampled.Sub(start)))
+	Rewire(task.mileStone, task.path, false)
+	task.path.Stats.RewireTime.Add(int64(time.Since(sampled)))
+	if task.updateCost {
+		task.UpdateCost()
+	}
+}
+
+// Propagate the new milestone's cost to its descendants, for tasks that do not
+// update costs internally
+func (task *PathUpdate) UpdateCost() {
+	if task.mileStone == nil {
+		return
+	}
+	start := time.Now()
+	task.mileStone.UpdateChildrenCost()
+	task.path.Stats.CostTime.Add(int64(time.Since(start)))
 }
 
 // Get new milestone
@@ -1,8 +1,6 @@
 package rrtstar
 
 import (
-	"math"
-	"math/rand"
 	"proj3-redesigned/robotpath"
 )
 
@@ -12,10 +10,10 @@ func SamplePoint(path *robotpath.Path) *robotpath.MileStone {
 	ms = nil
 
 	// Sample until valid milestone created
+	bounds := path.Config.Bounds()
 	for ms == nil {
-		randX := rand.Float32() * float32(path.Config.WinWidth)
-		randY := rand.Float32() * float32(path.Config.WinHeight)
-		ms = tryPathExtend(robotpath.NewMileStone(path.Config.NewPoint(randX, randY)), path)
+		pt := path.Sampler.Sample(bounds)
+		ms = tryPathExtend(robotpath.NewMileStone(pt), path)
 	}
 	return ms
 }
@@ -28,25 +26,24 @@ func tryPathExtend(ms *robotpath.MileStone, path *robotpath.Path) *robotpath.Mil
 
 	// Extend path to nearest neighbor and check if new position valid, if not
 	// restart the process by returning nil
-	extend(ms, nearest, path.Config.Visibility)
-	if !path.Config.Visible(ms.Point, nearest.Point) {
+	newDist := extend(ms, nearest, path)
+	if !path.Config.InBounds(ms.Point) || !path.EdgeVisible(nearest, ms.Point, newDist) {
+		path.Reject(ms.Point)
 		return nil
 	}
 
 	// Add the point to the path plan
-	newDist := robotpath.Distance(nearest.Point, ms.Point)
 	ms.SetParent(nearest, 0.0, newDist)
 	path.AddPoint(ms)
 
 	return ms
 }
 
-// Set milestone's new location as distance from its nearest neighbor to the
-// closest point in the direction of its current position
-func extend(ms *robotpath.MileStone, nearest *robotpath.MileStone, radius float32) {
-	length := robotpath.Distance(ms.Point, nearest.Point)
-	vis := float32(math.Min(float64(radius), float64(length)))
-
-	ms.Point.X = nearest.Point.X + (ms.Point.X-nearest.Point.X)*vis/length
-	ms.Point.Y = nearest.Point.Y + (ms.Point.Y-nearest.Point.Y)*vis/length
+// Set milestone's new location by steering from its nearest neighbor towards
+// its current position for at most the visibility radius, returns the length
+// of the local path travelled
+func extend(ms *robotpath.MileStone, nearest *robotpath.MileStone, path *robotpath.Path) float32 {
+	pt, dist := path.Steering.Steer(nearest.Point, ms.Point, path.Config.Visibility)
+	ms.Point = pt
+	return dist
 }
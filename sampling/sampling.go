@@ -0,0 +1,123 @@
+package sampling
+
+import (
+	"math"
+	"math/rand"
+	"proj3-redesigned/configspace"
+	"sync"
+)
+
+// Sampler draws random states within the bounds of a configuration space, it
+// must be safe to call from several goroutines
+type Sampler interface {
+	Sample(bounds []configspace.Limit) *configspace.Point
+}
+
+// Splitter is implemented by Samplers that can derive independent streams of
+// samples, so concurrent users can each draw reproducible samples whatever
+// order they run in
+type Splitter interface {
+	Split(stream int64) Sampler
+}
+
+// uniform implements Sampler by drawing each coordinate uniformly, planar
+// states also get a uniform heading
+type uniform struct {
+	seed int64      // Seed of the source, 0 for the global source
+	rng  *rand.Rand // Seeded source, nil for the global source
+	lock sync.Mutex // Lock for the seeded source
+}
+
+// Creates a new uniform Sampler, a zero seed uses the global random source
+func NewUniform(seed int64) Sampler {
+	if seed == 0 {
+		return &uniform{}
+	}
+	return &uniform{seed: seed, rng: rand.New(rand.NewSource(seed))}
+}
+
+// Split derives a uniform Sampler for a stream from the seed, the global
+// source cannot be split and is shared
+func (u *uniform) Split(stream int64) Sampler {
+	if u.rng == nil {
+		return u
+	}
+	return NewUniform(mixSeed(u.seed, stream))
+}
+
+// Mix a seed and a stream number into a new non-zero seed with the splitmix64
+// finalizer, so nearby streams get unrelated seeds
+func mixSeed(seed int64, stream int64) int64 {
+	z := uint64(seed) + uint64(stream+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+	if z == 0 {
+		z = 1
+	}
+	return int64(z)
+}
+
+// Draw a random number in [0, 1)
+func (u *uniform) float() float32 {
+	if u.rng == nil {
+		return rand.Float32()
+	}
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	return u.rng.Float32()
+}
+
+// Draw a state uniformly within the bounds
+func (u *uniform) Sample(bounds []configspace.Limit) *configspace.Point {
+	// Planar spaces sample a position and heading directly
+	if len(bounds) == 2 {
+		return &configspace.Point{
+			X:     bounds[0].Min + u.float()*(bounds[0].Max-bounds[0].Min),
+			Y:     bounds[1].Min + u.float()*(bounds[1].Max-bounds[1].Min),
+			Theta: u.float() * 2 * math.Pi,
+		}
+	}
+	coords := make([]float32, len(bounds))
+	for i, limit := range bounds {
+		coords[i] = limit.Min + u.float()*(limit.Max-limit.Min)
+	}
+	return configspace.NewPointN(coords)
+}
+
+// goalBiased implements Sampler by drawing the goal with a fixed probability
+// and otherwise deferring to another Sampler
+type goalBiased struct {
+	base *uniform           // Sampler for states other than the goal
+	goal *configspace.Point // Goal state
+	bias float32            // Probability of drawing the goal
+}
+
+// Creates a new goal-biased Sampler drawing the goal with probability bias
+func NewGoalBiased(seed int64, goal *configspace.Point, bias float32) Sampler {
+	return &goalBiased{
+		base: NewUniform(seed).(*uniform),
+		goal: goal,
+		bias: bias,
+	}
+}
+
+// Split derives a goal-biased Sampler for a stream from the seed
+func (g *goalBiased) Split(stream int64) Sampler {
+	return &goalBiased{
+		base: g.base.Split(stream).(*uniform),
+		goal: g.goal,
+		bias: g.bias,
+	}
+}
+
+// Draw the goal or a uniform state, the goal is copied since the tree may
+// move sampled states
+func (g *goalBiased) Sample(bounds []configspace.Limit) *configspace.Point {
+	if g.base.float() >= g.bias {
+		return g.base.Sample(bounds)
+	}
+	goal := *g.goal
+	goal.Q = append([]float32(nil), g.goal.Q...)
+	return &goal
+}
@@ -0,0 +1,96 @@
+package steering
+
+import (
+	"math"
+	"proj3-redesigned/configspace"
+)
+
+// Kind of motion making up a segment of a car-like local path
+type segmentKind int
+
+const (
+	left segmentKind = iota
+	straight
+	right
+)
+
+// Angle between chords used when interpolating turns
+const chordAngle = math.Pi / 16
+
+// curve is a local path of a car-like robot made up of three segments. Lengths
+// are normalized by the turning radius and negative lengths are driven in
+// reverse
+type curve struct {
+	kinds   [3]segmentKind
+	lengths [3]float64
+}
+
+// Total normalized length of the curve
+func (c *curve) length() float64 {
+	return math.Abs(c.lengths[0]) + math.Abs(c.lengths[1]) + math.Abs(c.lengths[2])
+}
+
+// State reached after travelling the normalized distance dist along the curve
+func (c *curve) at(from *configspace.Point, rho float64, dist float64) *configspace.Point {
+	x, y, theta := 0.0, 0.0, float64(from.Theta)
+	for i, kind := range c.kinds {
+		if dist <= 0 {
+			break
+		}
+		v := c.lengths[i]
+		if math.Abs(v) > dist {
+			v = math.Copysign(dist, v)
+		}
+		dist -= math.Abs(v)
+
+		switch kind {
+		case left:
+			x += math.Sin(theta+v) - math.Sin(theta)
+			y += -math.Cos(theta+v) + math.Cos(theta)
+			theta += v
+		case right:
+			x += -math.Sin(theta-v) + math.Sin(theta)
+			y += math.Cos(theta-v) - math.Cos(theta)
+			theta -= v
+		case straight:
+			x += v * math.Cos(theta)
+			y += v * math.Sin(theta)
+		}
+	}
+	return &configspace.Point{
+		X:     from.X + float32(x*rho),
+		Y:     from.Y + float32(y*rho),
+		Theta: float32(mod2pi(theta)),
+	}
+}
+
+// Follow the curve for at most radius
+func (c *curve) steer(from *configspace.Point, to *configspace.Point, rho float64,
+	radius float32,
+) (*configspace.Point, float32) {
+	length := float32(c.length() * rho)
+	if length <= radius {
+		return &configspace.Point{X: to.X, Y: to.Y, Theta: to.Theta}, length
+	}
+	return c.at(from, rho, float64(radius)/rho), radius
+}
+
+// States along the curve spaced by at most chordAngle of turning
+func (c *curve) interpolate(from *configspace.Point, to *configspace.Point, rho float64,
+) []*configspace.Point {
+	steps := int(math.Ceil(c.length() / chordAngle))
+	states := []*configspace.Point{from}
+	for i := 1; i < steps; i++ {
+		states = append(states, c.at(from, rho, c.length()*float64(i)/float64(steps)))
+	}
+	return append(states, to)
+}
+
+// Wrap an angle into [0, 2*pi)
+func mod2pi(theta float64) float64 {
+	theta = math.Mod(theta, 2*math.Pi)
+	if theta < 0 {
+		theta += 2 * math.Pi
+	}
+	return theta
+}
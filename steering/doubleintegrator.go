@@ -0,0 +1,172 @@
+package steering
+
+import (
+	"math"
+	"proj3-redesigned/configspace"
+)
+
+// Number of random controls tried when extending the tree
+const numControls = 10
+
+// Interpolated states per time step of a local path
+const stepsPerTimeStep = 8
+
+// doubleIntegrator implements Steering for a planar robot controlled by its
+// acceleration. States are (x, y, vx, vy) and distances are times
+type doubleIntegrator struct {
+	maxAcc   float64 // Maximum acceleration along each axis
+	maxVel   float64 // Maximum velocity along each axis
+	timeStep float64 // Duration each control is applied for
+}
+
+// Creates a new double integrator Steering
+func NewDoubleIntegrator(maxAcc float32, maxVel float32, timeStep float32) Steering {
+	return &doubleIntegrator{
+		maxAcc:   float64(maxAcc),
+		maxVel:   float64(maxVel),
+		timeStep: float64(timeStep),
+	}
+}
+
+// Lower bound on the time to reach one state from another, found by solving
+// the minimum time bang-bang problem for each axis separately
+func (d *doubleIntegrator) Distance(from *configspace.Point, to *configspace.Point) float32 {
+	tx := minTime(float64(from.X), float64(from.Q[0]), float64(to.X), float64(to.Q[0]), d.maxAcc)
+	ty := minTime(float64(from.Y), float64(from.Q[1]), float64(to.Y), float64(to.Q[1]), d.maxAcc)
+	return float32(math.Max(tx, ty))
+}
+
+// Forward-simulate random controls for one time step and keep the one that
+// ends closest to the target state. The controls are drawn from the two
+// states, so a seeded sampler's targets give the same extensions on every run
+// whatever the order they are steered in. The radius is not used since every
+// extension lasts one time step
+func (d *doubleIntegrator) Steer(from *configspace.Point, to *configspace.Point, radius float32,
+) (*configspace.Point, float32) {
+	controls := newControlSource(from, to)
+	var best *configspace.Point
+	bestDist := float32(math.Inf(1))
+	for i := 0; i < numControls; i++ {
+		ax := (2*controls.Float64() - 1) * d.maxAcc
+		ay := (2*controls.Float64() - 1) * d.maxAcc
+		pt := d.simulate(from, ax, ay, d.timeStep)
+		if math.Abs(float64(pt.Q[0])) > d.maxVel || math.Abs(float64(pt.Q[1])) > d.maxVel {
+			continue
+		}
+		if dist := d.Distance(pt, to); dist < bestDist {
+			best, bestDist = pt, dist
+		}
+	}
+	if best == nil {
+		// Every control broke the velocity limit, so coast
+		best = d.simulate(from, 0, 0, d.timeStep)
+	}
+	return best, float32(d.timeStep)
+}
+
+// controlSource draws random controls with the splitmix64 generator
+type controlSource struct {
+	state uint64
+}
+
+// Create a control source seeded by the coordinates of two states
+func newControlSource(from *configspace.Point, to *configspace.Point) *controlSource {
+	c := &controlSource{}
+	for _, pt := range []*configspace.Point{from, to} {
+		for i := 0; i < pt.Dim(); i++ {
+			c.state = (c.state ^ uint64(math.Float32bits(pt.Coord(i)))) * 0x100000001b3
+			c.next()
+		}
+	}
+	return c
+}
+
+// Get the next 64 random bits
+func (c *controlSource) next() uint64 {
+	c.state += 0x9e3779b97f4a7c15
+	z := c.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// Draw a number in [0, 1)
+func (c *controlSource) Float64() float64 {
+	return float64(c.next()>>11) / (1 << 53)
+}
+
+// States along the cubic trajectory matching both positions and velocities.
+// Tree edges are constant acceleration arcs, whose duration is recovered
+// exactly from their end states, other pairs use the time lower bound
+func (d *doubleIntegrator) Interpolate(from *configspace.Point, to *configspace.Point,
+) []*configspace.Point {
+	dx, dy := float64(to.X-from.X), float64(to.Y-from.Y)
+	sx, sy := float64(from.Q[0]+to.Q[0]), float64(from.Q[1]+to.Q[1])
+	duration := 2 * (dx*sx + dy*sy) / (sx*sx + sy*sy)
+	if math.IsNaN(duration) || math.IsInf(duration, 0) || duration <= 0 {
+		duration = float64(d.Distance(from, to))
+	}
+
+	steps := int(math.Max(1, math.Ceil(stepsPerTimeStep*duration/d.timeStep)))
+	states := []*configspace.Point{from}
+	for i := 1; i < steps; i++ {
+		states = append(states, hermite(from, to, duration, float64(i)/float64(steps)))
+	}
+	return append(states, to)
+}
+
+// Local paths only connect states that forward simulation produced
+func (d *doubleIntegrator) Exact() bool {
+	return false
+}
+
+// State after applying a constant acceleration for the given time
+func (d *doubleIntegrator) simulate(from *configspace.Point, ax float64, ay float64,
+	t float64,
+) *configspace.Point {
+	vx, vy := float64(from.Q[0]), float64(from.Q[1])
+	return &configspace.Point{
+		X: from.X + float32(vx*t+0.5*ax*t*t),
+		Y: from.Y + float32(vy*t+0.5*ay*t*t),
+		Q: []float32{float32(vx + ax*t), float32(vy + ay*t)},
+	}
+}
+
+// State a fraction of the way along the cubic Hermite curve between two states
+func hermite(from *configspace.Point, to *configspace.Point, duration float64,
+	frac float64,
+) *configspace.Point {
+	s2, s3 := frac*frac, frac*frac*frac
+	h00, h10 := 2*s3-3*s2+1, s3-2*s2+frac
+	h01, h11 := -2*s3+3*s2, s3-s2
+	d00, d10 := (6*s2-6*frac)/duration, 3*s2-4*frac+1
+	d01, d11 := (-6*s2+6*frac)/duration, 3*s2-2*frac
+
+	coord := func(p0, v0, p1, v1 float32) (float32, float32) {
+		pos := h00*float64(p0) + h10*duration*float64(v0) + h01*float64(p1) + h11*duration*float64(v1)
+		vel := d00*float64(p0) + d10*float64(v0) + d01*float64(p1) + d11*float64(v1)
+		return float32(pos), float32(vel)
+	}
+	x, vx := coord(from.X, from.Q[0], to.X, to.Q[0])
+	y, vy := coord(from.Y, from.Q[1], to.Y, to.Q[1])
+	return &configspace.Point{X: x, Y: y, Q: []float32{vx, vy}}
+}
+
+// Minimum time for a 1D double integrator with bounded acceleration to move
+// between two states, by accelerating fully one way then the other
+func minTime(p0 float64, v0 float64, p1 float64, v1 float64, acc float64) float64 {
+	best := math.Inf(1)
+	for _, sign := range []float64{1, -1} {
+		sq := (v0*v0+v1*v1)/2 + sign*acc*(p1-p0)
+		if sq < 0 {
+			continue
+		}
+		peak := sign * math.Sqrt(sq)
+		t1 := (peak - v0) / (sign * acc)
+		t2 := (peak - v1) / (sign * acc)
+		if t1 >= -1e-9 && t2 >= -1e-9 {
+			best = math.Min(best, t1+t2)
+		}
+	}
+	return best
+}
@@ -0,0 +1,113 @@
+// Dubins path formulas adapted from Shkel and Lumelsky, "Classification of the
+// Dubins set", and the reference implementation at:
+// https://github.com/AndrewWalker/Dubins-Curves
+
+package steering
+
+import (
+	"math"
+	"proj3-redesigned/configspace"
+)
+
+// dubins implements Steering for a forward-only car with a minimum turning
+// radius
+type dubins struct {
+	rho float64 // Turning radius
+}
+
+// Creates a new Dubins Steering
+func NewDubins(turnRadius float32) Steering {
+	return &dubins{rho: float64(turnRadius)}
+}
+
+// Length of the shortest Dubins path between the two states
+func (d *dubins) Distance(from *configspace.Point, to *configspace.Point) float32 {
+	c := shortestDubins(from, to, d.rho)
+	return float32(c.length() * d.rho)
+}
+
+// Follow the shortest Dubins path for at most radius
+func (d *dubins) Steer(from *configspace.Point, to *configspace.Point, radius float32,
+) (*configspace.Point, float32) {
+	c := shortestDubins(from, to, d.rho)
+	return c.steer(from, to, d.rho, radius)
+}
+
+// States along the shortest Dubins path
+func (d *dubins) Interpolate(from *configspace.Point, to *configspace.Point,
+) []*configspace.Point {
+	c := shortestDubins(from, to, d.rho)
+	return c.interpolate(from, to, d.rho)
+}
+
+// Local paths connect any two states
+func (d *dubins) Exact() bool {
+	return true
+}
+
+// Find the shortest of the six Dubins words between the two states
+func shortestDubins(from *configspace.Point, to *configspace.Point, rho float64) *curve {
+	dx, dy := float64(to.X-from.X), float64(to.Y-from.Y)
+	dist := math.Hypot(dx, dy) / rho
+	theta := mod2pi(math.Atan2(dy, dx))
+	alpha := mod2pi(float64(from.Theta) - theta)
+	beta := mod2pi(float64(to.Theta) - theta)
+
+	sa, sb := math.Sin(alpha), math.Sin(beta)
+	ca, cb := math.Cos(alpha), math.Cos(beta)
+	cab := math.Cos(alpha - beta)
+
+	var best *curve
+	try := func(kinds [3]segmentKind, t, p, q float64, ok bool) {
+		c := &curve{kinds: kinds, lengths: [3]float64{t, p, q}}
+		if ok && (best == nil || c.length() < best.length()) {
+			best = c
+		}
+	}
+
+	// LSL
+	pSq := 2 + dist*dist - 2*cab + 2*dist*(sa-sb)
+	tmp := math.Atan2(cb-ca, dist+sa-sb)
+	try([3]segmentKind{left, straight, left},
+		mod2pi(tmp-alpha), math.Sqrt(math.Max(pSq, 0)), mod2pi(beta-tmp), pSq >= 0)
+
+	// RSR
+	pSq = 2 + dist*dist - 2*cab + 2*dist*(sb-sa)
+	tmp = math.Atan2(ca-cb, dist-sa+sb)
+	try([3]segmentKind{right, straight, right},
+		mod2pi(alpha-tmp), math.Sqrt(math.Max(pSq, 0)), mod2pi(tmp-beta), pSq >= 0)
+
+	// LSR
+	pSq = -2 + dist*dist + 2*cab + 2*dist*(sa+sb)
+	p := math.Sqrt(math.Max(pSq, 0))
+	tmp = math.Atan2(-ca-cb, dist+sa+sb) - math.Atan2(-2, p)
+	try([3]segmentKind{left, straight, right},
+		mod2pi(tmp-alpha), p, mod2pi(tmp-beta), pSq >= 0)
+
+	// RSL
+	pSq = -2 + dist*dist + 2*cab - 2*dist*(sa+sb)
+	p = math.Sqrt(math.Max(pSq, 0))
+	tmp = math.Atan2(ca+cb, dist-sa-sb) - math.Atan2(2, p)
+	try([3]segmentKind{right, straight, left},
+		mod2pi(alpha-tmp), p, mod2pi(beta-tmp), pSq >= 0)
+
+	// RLR
+	tmp = (6 - dist*dist + 2*cab + 2*dist*(sa-sb)) / 8
+	if math.Abs(tmp) <= 1 {
+		phi := math.Atan2(ca-cb, dist-sa+sb)
+		p = mod2pi(2*math.Pi - math.Acos(tmp))
+		t := mod2pi(alpha - phi + p/2)
+		try([3]segmentKind{right, left, right}, t, p, mod2pi(alpha-beta-t+p), true)
+	}
+
+	// LRL
+	tmp = (6 - dist*dist + 2*cab + 2*dist*(sb-sa)) / 8
+	if math.Abs(tmp) <= 1 {
+		phi := math.Atan2(ca-cb, dist+sa-sb)
+		p = mod2pi(2*math.Pi - math.Acos(tmp))
+		t := mod2pi(-alpha - phi + p/2)
+		try([3]segmentKind{left, right, left}, t, p, mod2pi(beta-alpha-t+p), true)
+	}
+
+	return best
+}
@@ -0,0 +1,174 @@
+// Reeds-Shepp path formulas adapted from Reeds and Shepp, "Optimal paths for a
+// car that goes both forwards and backwards", and the OMPL implementation at:
+// https://github.com/ompl/ompl/blob/main/src/ompl/base/spaces/src/ReedsSheppStateSpace.cpp
+//
+// Only the CSC and CCC families (with their time-flip, reflection and
+// backwards variants) are searched, so paths may be longer than optimal when
+// the shortest path needs four or five segments. The forward-only Dubins path
+// is used when none of the searched words apply.
+
+package steering
+
+import (
+	"math"
+	"proj3-redesigned/configspace"
+)
+
+// Tolerance used when checking segment length signs
+const rsZero = -1e-9
+
+// reedsShepp implements Steering for a car that can reverse
+type reedsShepp struct {
+	rho float64 // Turning radius
+}
+
+// Creates a new Reeds-Shepp Steering
+func NewReedsShepp(turnRadius float32) Steering {
+	return &reedsShepp{rho: float64(turnRadius)}
+}
+
+// Length of the shortest Reeds-Shepp path found between the two states
+func (r *reedsShepp) Distance(from *configspace.Point, to *configspace.Point) float32 {
+	c := shortestReedsShepp(from, to, r.rho)
+	return float32(c.length() * r.rho)
+}
+
+// Follow the Reeds-Shepp path for at most radius
+func (r *reedsShepp) Steer(from *configspace.Point, to *configspace.Point, radius float32,
+) (*configspace.Point, float32) {
+	c := shortestReedsShepp(from, to, r.rho)
+	return c.steer(from, to, r.rho, radius)
+}
+
+// States along the Reeds-Shepp path
+func (r *reedsShepp) Interpolate(from *configspace.Point, to *configspace.Point,
+) []*configspace.Point {
+	c := shortestReedsShepp(from, to, r.rho)
+	return c.interpolate(from, to, r.rho)
+}
+
+// Local paths connect any two states
+func (r *reedsShepp) Exact() bool {
+	return true
+}
+
+// Find the shortest searched Reeds-Shepp word between the two states
+func shortestReedsShepp(from *configspace.Point, to *configspace.Point, rho float64) *curve {
+	// Express the goal in the start's frame, normalized by the turning radius
+	dx, dy := float64(to.X-from.X), float64(to.Y-from.Y)
+	c, s := math.Cos(float64(from.Theta)), math.Sin(float64(from.Theta))
+	x, y := (c*dx+s*dy)/rho, (-s*dx+c*dy)/rho
+	phi := float64(to.Theta - from.Theta)
+
+	best := shortestDubins(from, to, rho)
+	try := func(kinds [3]segmentKind, t, u, v float64) {
+		cand := &curve{kinds: kinds, lengths: [3]float64{t, u, v}}
+		if cand.length() < best.length() {
+			best = cand
+		}
+	}
+	lsl := [3]segmentKind{left, straight, left}
+	rsr := [3]segmentKind{right, straight, right}
+	lsr := [3]segmentKind{left, straight, right}
+	rsl := [3]segmentKind{right, straight, left}
+	lrl := [3]segmentKind{left, right, left}
+	rlr := [3]segmentKind{right, left, right}
+
+	// CSC family with time-flip and reflection
+	if t, u, v, ok := lpSpLp(x, y, phi); ok {
+		try(lsl, t, u, v)
+	}
+	if t, u, v, ok := lpSpLp(-x, y, -phi); ok {
+		try(lsl, -t, -u, -v)
+	}
+	if t, u, v, ok := lpSpLp(x, -y, -phi); ok {
+		try(rsr, t, u, v)
+	}
+	if t, u, v, ok := lpSpLp(-x, -y, phi); ok {
+		try(rsr, -t, -u, -v)
+	}
+	if t, u, v, ok := lpSpRp(x, y, phi); ok {
+		try(lsr, t, u, v)
+	}
+	if t, u, v, ok := lpSpRp(-x, y, -phi); ok {
+		try(lsr, -t, -u, -v)
+	}
+	if t, u, v, ok := lpSpRp(x, -y, -phi); ok {
+		try(rsl, t, u, v)
+	}
+	if t, u, v, ok := lpSpRp(-x, -y, phi); ok {
+		try(rsl, -t, -u, -v)
+	}
+
+	// CCC family with time-flip and reflection
+	if t, u, v, ok := lpRmL(x, y, phi); ok {
+		try(lrl, t, u, v)
+	}
+	if t, u, v, ok := lpRmL(-x, y, -phi); ok {
+		try(lrl, -t, -u, -v)
+	}
+	if t, u, v, ok := lpRmL(x, -y, -phi); ok {
+		try(rlr, t, u, v)
+	}
+	if t, u, v, ok := lpRmL(-x, -y, phi); ok {
+		try(rlr, -t, -u, -v)
+	}
+
+	// CCC family driven backwards
+	xb := x*math.Cos(phi) + y*math.Sin(phi)
+	yb := x*math.Sin(phi) - y*math.Cos(phi)
+	if t, u, v, ok := lpRmL(xb, yb, phi); ok {
+		try(lrl, v, u, t)
+	}
+	if t, u, v, ok := lpRmL(-xb, yb, -phi); ok {
+		try(lrl, -v, -u, -t)
+	}
+	if t, u, v, ok := lpRmL(xb, -yb, -phi); ok {
+		try(rlr, v, u, t)
+	}
+	if t, u, v, ok := lpRmL(-xb, -yb, phi); ok {
+		try(rlr, -v, -u, -t)
+	}
+
+	return best
+}
+
+// Formula 8.1 of Reeds and Shepp
+func lpSpLp(x, y, phi float64) (float64, float64, float64, bool) {
+	u, t := polar(x-math.Sin(phi), y-1+math.Cos(phi))
+	if t < rsZero {
+		return 0, 0, 0, false
+	}
+	v := mod2pi(phi - t)
+	return t, u, v, v >= rsZero
+}
+
+// Formula 8.2 of Reeds and Shepp
+func lpSpRp(x, y, phi float64) (float64, float64, float64, bool) {
+	u1, t1 := polar(x+math.Sin(phi), y-1-math.Cos(phi))
+	u1 = u1 * u1
+	if u1 < 4 {
+		return 0, 0, 0, false
+	}
+	u := math.Sqrt(u1 - 4)
+	t := mod2pi(t1 + math.Atan2(2, u))
+	v := mod2pi(t - phi)
+	return t, u, v, t >= rsZero && v >= rsZero
+}
+
+// Formula 8.3 of Reeds and Shepp, with the correction used by OMPL
+func lpRmL(x, y, phi float64) (float64, float64, float64, bool) {
+	u1, theta := polar(x-math.Sin(phi), y-1+math.Cos(phi))
+	if u1 > 4 {
+		return 0, 0, 0, false
+	}
+	u := -2 * math.Asin(0.25*u1)
+	t := mod2pi(theta + 0.5*u + math.Pi)
+	v := mod2pi(phi - t + u)
+	return t, u, v, t >= rsZero && u <= -rsZero
+}
+
+// Polar coordinates of a vector
+func polar(x, y float64) (float64, float64) {
+	return math.Hypot(x, y), math.Atan2(y, x)
+}
@@ -0,0 +1,73 @@
+package steering
+
+import (
+	"math"
+	"proj3-redesigned/configspace"
+)
+
+// se2 implements Steering for a holonomic robot that translates and rotates
+// at the same time, so its orientation matters
+type se2 struct {
+	rotWeight  float64 // Length equivalent of one radian of rotation
+	resolution float64 // Maximum weighted distance between interpolated states
+}
+
+// Creates a new SE(2) Steering. Rotations are weighted by rotWeight in the
+// distance metric and local paths are interpolated every resolution
+func NewSE2(rotWeight float32, resolution float32) Steering {
+	return &se2{rotWeight: float64(rotWeight), resolution: float64(resolution)}
+}
+
+// Weighted distance between the two states
+func (s *se2) Distance(from *configspace.Point, to *configspace.Point) float32 {
+	return float32(s.distance(from, to))
+}
+
+// Move towards the state along the straight line in (x, y, theta)
+func (s *se2) Steer(from *configspace.Point, to *configspace.Point, radius float32,
+) (*configspace.Point, float32) {
+	length := s.distance(from, to)
+	if length <= float64(radius) {
+		return &configspace.Point{X: to.X, Y: to.Y, Theta: to.Theta}, float32(length)
+	}
+	return s.lerp(from, to, float64(radius)/length), radius
+}
+
+// States along the local path spaced by at most the resolution
+func (s *se2) Interpolate(from *configspace.Point, to *configspace.Point,
+) []*configspace.Point {
+	steps := int(math.Ceil(s.distance(from, to) / s.resolution))
+	states := []*configspace.Point{from}
+	for i := 1; i < steps; i++ {
+		states = append(states, s.lerp(from, to, float64(i)/float64(steps)))
+	}
+	return append(states, to)
+}
+
+// Local paths connect any two states
+func (s *se2) Exact() bool {
+	return true
+}
+
+// Weighted distance between the two states
+func (s *se2) distance(from *configspace.Point, to *configspace.Point) float64 {
+	dx, dy := float64(to.X-from.X), float64(to.Y-from.Y)
+	dTheta := s.rotWeight * angleDiff(from.Theta, to.Theta)
+	return math.Sqrt(dx*dx + dy*dy + dTheta*dTheta)
+}
+
+// State a fraction of the way from one state to another, turning the short
+// way around
+func (s *se2) lerp(from *configspace.Point, to *configspace.Point, frac float64,
+) *configspace.Point {
+	return &configspace.Point{
+		X:     from.X + float32(frac)*(to.X-from.X),
+		Y:     from.Y + float32(frac)*(to.Y-from.Y),
+		Theta: float32(mod2pi(float64(from.Theta) + frac*angleDiff(from.Theta, to.Theta))),
+	}
+}
+
+// Signed shortest rotation from one heading to another
+func angleDiff(from float32, to float32) float64 {
+	return mod2pi(float64(to-from)+math.Pi) - math.Pi
+}
@@ -0,0 +1,97 @@
+package steering
+
+import (
+	"math"
+	"proj3-redesigned/configspace"
+)
+
+// Steering describes how the robot moves between two states of the
+// configuration space
+type Steering interface {
+	// Distance returns the length of the local path between the two states
+	Distance(from *configspace.Point, to *configspace.Point) float32
+
+	// Steer follows the local path from one state towards another for at most
+	// radius and returns the state reached along with the length travelled
+	Steer(from *configspace.Point, to *configspace.Point, radius float32,
+	) (*configspace.Point, float32)
+
+	// Interpolate returns states along the local path that are close enough
+	// together to be connected by line segments, including both end states
+	Interpolate(from *configspace.Point, to *configspace.Point) []*configspace.Point
+
+	// Exact reports whether Interpolate connects any two states exactly, which
+	// rewiring requires
+	Exact() bool
+}
+
+// New returns the steering method named in the configuration space. Unknown
+// names steer in straight lines
+func New(config *configspace.Config) Steering {
+	switch config.Steering {
+	case "dubins":
+		return NewDubins(config.TurnRadius)
+	case "reedsshepp":
+		return NewReedsShepp(config.TurnRadius)
+	case "se2":
+		// Interpolate finely enough that the footprint's sweep is tracked
+		resolution := config.Visibility / 10
+		if config.Robot != nil {
+			resolution = config.Robot.Radius() / 8
+		}
+		return NewSE2(config.RotWeight, resolution)
+	case "doubleintegrator":
+		return NewDoubleIntegrator(config.MaxAcc, config.MaxVel, config.TimeStep)
+	}
+	return NewStraightLine()
+}
+
+// straightLine implements Steering for a holonomic point robot
+type straightLine struct{}
+
+// Creates a new straight line Steering
+func NewStraightLine() Steering {
+	return &straightLine{}
+}
+
+// Euclidean distance between the two states
+func (s *straightLine) Distance(from *configspace.Point, to *configspace.Point) float32 {
+	// Planar states skip the loop over extra coordinates
+	if len(from.Q) == 0 {
+		return float32(math.Hypot(float64(to.X-from.X), float64(to.Y-from.Y)))
+	}
+	var sq float64
+	for i := 0; i < from.Dim(); i++ {
+		diff := float64(to.Coord(i) - from.Coord(i))
+		sq += diff * diff
+	}
+	return float32(math.Sqrt(sq))
+}
+
+// Move towards the state in a straight line, the heading is left unchanged
+func (s *straightLine) Steer(from *configspace.Point, to *configspace.Point, radius float32,
+) (*configspace.Point, float32) {
+	length := s.Distance(from, to)
+	pt := &configspace.Point{X: to.X, Y: to.Y, Theta: to.Theta}
+	if len(to.Q) > 0 {
+		pt.Q = append([]float32{}, to.Q...)
+	}
+	if length <= radius {
+		return pt, length
+	}
+	for i := 0; i < pt.Dim(); i++ {
+		pt.SetCoord(i, from.Coord(i)+(to.Coord(i)-from.Coord(i))*radius/length)
+	}
+	return pt, radius
+}
+
+// A straight local path only needs its end states
+func (s *straightLine) Interpolate(from *configspace.Point, to *configspace.Point,
+) []*configspace.Point {
+	return []*configspace.Point{from, to}
+}
+
+// Straight lines connect any two states
+func (s *straightLine) Exact() bool {
+	return true
+}
@@ -0,0 +1,130 @@
+package steering
+
+// Unit testing for the Steering implementations. Tests the following:
+// straightLine Steer
+// shortestDubins
+// shortestReedsShepp
+// doubleIntegrator Steer
+//
+
+import (
+	"math"
+	"math/rand"
+	"proj3-redesigned/configspace"
+	"testing"
+)
+
+// Check that a curve ends at the target state
+func checkEndState(t *testing.T, name string, c *curve, from, to *configspace.Point, rho float64) {
+	end := c.at(from, rho, c.length())
+	dTheta := math.Abs(mod2pi(float64(end.Theta-to.Theta)+math.Pi) - math.Pi)
+	if math.Hypot(float64(end.X-to.X), float64(end.Y-to.Y)) > 1e-2 || dTheta > 1e-3 {
+		t.Errorf("%s from %+v to %+v ended at %+v", name, *from, *to, *end)
+	}
+}
+
+// Test straight line steering is limited by the radius
+func TestStraightLineSteer(t *testing.T) {
+	s := NewStraightLine()
+	from := &configspace.Point{X: 0, Y: 0}
+	pt, dist := s.Steer(from, &configspace.Point{X: 30, Y: 40}, 10)
+	if pt.X != 6 || pt.Y != 8 || dist != 10 {
+		t.Errorf("Expected (6, 8) at 10, got %+v at %v", *pt, dist)
+	}
+	pt, dist = s.Steer(from, &configspace.Point{X: 3, Y: 4}, 10)
+	if pt.X != 3 || pt.Y != 4 || dist != 5 {
+		t.Errorf("Expected (3, 4) at 5, got %+v at %v", *pt, dist)
+	}
+}
+
+// Test Dubins and Reeds-Shepp paths end at the target state
+func TestCurvesReachTarget(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		from := &configspace.Point{
+			X: rng.Float32() * 100, Y: rng.Float32() * 100, Theta: rng.Float32() * 2 * math.Pi,
+		}
+		to := &configspace.Point{
+			X: rng.Float32() * 100, Y: rng.Float32() * 100, Theta: rng.Float32() * 2 * math.Pi,
+		}
+		dubins := shortestDubins(from, to, 10)
+		checkEndState(t, "Dubins", dubins, from, to, 10)
+		rs := shortestReedsShepp(from, to, 10)
+		checkEndState(t, "Reeds-Shepp", rs, from, to, 10)
+		if rs.length() > dubins.length()+1e-9 {
+			t.Errorf("Reeds-Shepp longer than Dubins from %+v to %+v", *from, *to)
+		}
+	}
+}
+
+// Test reversing is shorter than turning around for a car that can reverse
+func TestReedsSheppReverse(t *testing.T) {
+	from := &configspace.Point{X: 0, Y: 0, Theta: 0}
+	to := &configspace.Point{X: -10, Y: 0, Theta: 0}
+	if d := NewReedsShepp(5).Distance(from, to); math.Abs(float64(d)-10) > 1e-3 {
+		t.Errorf("Expected straight reverse of 10, got %v", d)
+	}
+	if d := NewDubins(5).Distance(from, to); d <= 10 {
+		t.Errorf("Expected Dubins to turn around, got %v", d)
+	}
+}
+
+// Test a truncated curve is a prefix of the full curve
+func TestCurveSteer(t *testing.T) {
+	s := NewDubins(10)
+	from := &configspace.Point{X: 0, Y: 0, Theta: 0}
+	to := &configspace.Point{X: 0, Y: 50, Theta: math.Pi}
+	pt, dist := s.Steer(from, to, 15)
+	if dist != 15 {
+		t.Errorf("Expected steer of 15, got %v", dist)
+	}
+	rest := s.Distance(pt, to)
+	if math.Abs(float64(rest+dist-s.Distance(from, to))) > 1e-2 {
+		t.Errorf("Expected remaining distance %v, got %v", s.Distance(from, to)-dist, rest)
+	}
+}
+
+// Test the 1D minimum time of the double integrator
+func TestMinTime(t *testing.T) {
+	// Rest to rest over 4 units at 2 accelerates for 1.414s each way
+	if tm := minTime(0, 0, 4, 0, 2); math.Abs(tm-2*math.Sqrt2) > 1e-9 {
+		t.Errorf("Expected %v, got %v", 2*math.Sqrt2, tm)
+	}
+	// Moving at the right speed towards a stop takes only the braking phase
+	if tm := minTime(0, 2, 1, 0, 2); math.Abs(tm-1) > 1e-9 {
+		t.Errorf("Expected 1, got %v", tm)
+	}
+}
+
+// Test the double integrator's tree edges are reproduced by Interpolate
+func TestDoubleIntegratorInterpolate(t *testing.T) {
+	d := NewDoubleIntegrator(5, 10, 0.5).(*doubleIntegrator)
+	from := &configspace.Point{X: 1, Y: 2, Q: []float32{3, -1}}
+	to := d.simulate(from, 2, 4, 0.5)
+	states := d.Interpolate(from, to)
+	mid := states[len(states)/2]
+	want := d.simulate(from, 2, 4, 0.25)
+	if math.Abs(float64(mid.X-want.X)) > 1e-4 || math.Abs(float64(mid.Q[1]-want.Q[1])) > 1e-4 {
+		t.Errorf("Expected midpoint %+v, got %+v", *want, *mid)
+	}
+	if d.Exact() {
+		t.Error("Expected double integrator steering to be inexact")
+	}
+}
+
+// Test the double integrator steers the same states to the same extension,
+// and different targets to different controls
+func TestDoubleIntegratorSteerRepeatable(t *testing.T) {
+	d := NewDoubleIntegrator(5, 10, 0.5)
+	from := &configspace.Point{X: 1, Y: 2, Q: []float32{0, 0}}
+	to := &configspace.Point{X: 30, Y: 40, Q: []float32{1, 1}}
+	first, _ := d.Steer(from, to, 10)
+	second, _ := d.Steer(from, to, 10)
+	if first.X != second.X || first.Y != second.Y || first.Q[0] != second.Q[0] || first.Q[1] != second.Q[1] {
+		t.Errorf("Expected equal extensions, got %+v and %+v", *first, *second)
+	}
+	other, _ := d.Steer(from, &configspace.Point{X: 30, Y: 41, Q: []float32{1, 1}}, 10)
+	if other.X == first.X && other.Y == first.Y {
+		t.Errorf("Expected different controls for another target, got %+v", *other)
+	}
+}
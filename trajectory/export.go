@@ -0,0 +1,37 @@
+package trajectory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// WriteCSV writes the states as CSV rows with a header
+func WriteCSV(w io.Writer, states []State) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"t", "x", "y", "vx", "vy"}); err != nil {
+		return err
+	}
+	for _, s := range states {
+		row := []string{
+			strconv.FormatFloat(float64(s.T), 'f', -1, 32),
+			strconv.FormatFloat(float64(s.X), 'f', -1, 32),
+			strconv.FormatFloat(float64(s.Y), 'f', -1, 32),
+			strconv.FormatFloat(float64(s.VX), 'f', -1, 32),
+			strconv.FormatFloat(float64(s.VY), 'f', -1, 32),
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// WriteJSON writes the states as a JSON array
+func WriteJSON(w io.Writer, states []State) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(states)
+}
@@ -0,0 +1,197 @@
+// Trapezoidal time-parameterization of a waypoint path. Corner blending uses
+// the junction deviation approach popularized by CNC motion planners such as
+// grbl: https://onehossshay.wordpress.com/2011/09/24/improving_grbl_cornering_algorithm/
+
+package trajectory
+
+import (
+	"errors"
+	"math"
+	"proj3-redesigned/configspace"
+)
+
+// Limits bounds the robot's motion along the path
+type Limits struct {
+	MaxVel float32 // Maximum speed
+	MaxAcc float32 // Maximum acceleration
+	Blend  float32 // Corner deviation tolerance, zero stops at every waypoint
+}
+
+// State is a timestamped position and velocity along the trajectory
+type State struct {
+	T  float32 `json:"t"`  // Time since start
+	X  float32 `json:"x"`  // X position
+	Y  float32 `json:"y"`  // Y position
+	VX float32 `json:"vx"` // X velocity
+	VY float32 `json:"vy"` // Y velocity
+}
+
+// Trajectory is a time-parameterized waypoint path
+type Trajectory struct {
+	segments []*segment // Straight segments between waypoints
+	Duration float32    // Total time to traverse the path
+}
+
+// segment is a single straight line traversed with a trapezoidal profile
+type segment struct {
+	start   float64 // Time the segment is entered
+	x0, y0  float64 // Start position
+	ux, uy  float64 // Unit direction
+	length  float64 // Segment length
+	v0, v1  float64 // Entry and exit speed
+	peak    float64 // Cruise speed
+	acc     float64 // Acceleration magnitude
+	tAcc    float64 // Duration of acceleration phase
+	tCruise float64 // Duration of cruise phase
+	tDec    float64 // Duration of deceleration phase
+}
+
+// New creates a trajectory through the waypoints that respects the limits
+func New(waypoints []*configspace.Point, limits Limits) (*Trajectory, error) {
+	if limits.MaxVel <= 0 || limits.MaxAcc <= 0 {
+		return nil, errors.New("trajectory: velocity and acceleration limits must be positive")
+	}
+	if limits.Blend < 0 {
+		return nil, errors.New("trajectory: blend tolerance must not be negative")
+	}
+
+	// Drop repeated waypoints since they have no direction
+	var pts []*configspace.Point
+	for _, pt := range waypoints {
+		if len(pts) == 0 || pt.X != pts[len(pts)-1].X || pt.Y != pts[len(pts)-1].Y {
+			pts = append(pts, pt)
+		}
+	}
+	if len(pts) < 2 {
+		return nil, errors.New("trajectory: need at least two distinct waypoints")
+	}
+
+	vMax, aMax := float64(limits.MaxVel), float64(limits.MaxAcc)
+
+	// Build straight segments between waypoints
+	segments := make([]*segment, len(pts)-1)
+	for i := range segments {
+		dx := float64(pts[i+1].X - pts[i].X)
+		dy := float64(pts[i+1].Y - pts[i].Y)
+		length := math.Hypot(dx, dy)
+		segments[i] = &segment{
+			x0:     float64(pts[i].X),
+			y0:     float64(pts[i].Y),
+			ux:     dx / length,
+			uy:     dy / length,
+			length: length,
+			acc:    aMax,
+		}
+	}
+
+	// Junction speeds between segments, the robot starts and ends at rest
+	junctions := make([]float64, len(pts))
+	for i := 1; i < len(pts)-1; i++ {
+		junctions[i] = junctionSpeed(segments[i-1], segments[i], vMax, aMax,
+			float64(limits.Blend))
+	}
+
+	// Backward then forward pass so every junction speed is reachable
+	for i := len(segments) - 1; i >= 0; i-- {
+		reach := math.Sqrt(junctions[i+1]*junctions[i+1] + 2*aMax*segments[i].length)
+		junctions[i] = math.Min(junctions[i], reach)
+	}
+	for i := 0; i < len(segments); i++ {
+		reach := math.Sqrt(junctions[i]*junctions[i] + 2*aMax*segments[i].length)
+		junctions[i+1] = math.Min(junctions[i+1], reach)
+	}
+
+	// Fit a trapezoidal profile to each segment
+	var elapsed float64
+	for i, seg := range segments {
+		seg.start = elapsed
+		seg.fit(junctions[i], junctions[i+1], vMax)
+		elapsed += seg.tAcc + seg.tCruise + seg.tDec
+	}
+
+	return &Trajectory{
+		segments: segments,
+		Duration: float32(elapsed),
+	}, nil
+}
+
+// Maximum speed through the junction of two segments that keeps the robot
+// within the blend tolerance of the corner
+func junctionSpeed(in *segment, out *segment, vMax, aMax, blend float64) float64 {
+	if blend == 0 {
+		return 0
+	}
+	cosTheta := -(in.ux*out.ux + in.uy*out.uy)
+	sinHalf := math.Sqrt(math.Max(0, 0.5*(1-cosTheta)))
+	if sinHalf >= 1 {
+		// Segments are collinear
+		return vMax
+	}
+	return math.Min(vMax, math.Sqrt(aMax*blend*sinHalf/(1-sinHalf)))
+}
+
+// Fit the trapezoidal profile given the entry and exit speeds
+func (seg *segment) fit(v0, v1, vMax float64) {
+	seg.v0, seg.v1 = v0, v1
+
+	// Peak speed is capped if the segment is too short to reach vMax
+	peak := math.Sqrt((2*seg.acc*seg.length + v0*v0 + v1*v1) / 2)
+	seg.peak = math.Max(math.Min(vMax, peak), math.Max(v0, v1))
+
+	dAcc := (seg.peak*seg.peak - v0*v0) / (2 * seg.acc)
+	dDec := (seg.peak*seg.peak - v1*v1) / (2 * seg.acc)
+	dCruise := math.Max(0, seg.length-dAcc-dDec)
+
+	seg.tAcc = (seg.peak - v0) / seg.acc
+	seg.tDec = (seg.peak - v1) / seg.acc
+	seg.tCruise = dCruise / seg.peak
+}
+
+// Distance travelled and speed at time tau after entering the segment
+func (seg *segment) eval(tau float64) (float64, float64) {
+	switch {
+	case tau <= 0:
+		return 0, seg.v0
+	case tau < seg.tAcc:
+		return seg.v0*tau + 0.5*seg.acc*tau*tau, seg.v0 + seg.acc*tau
+	case tau < seg.tAcc+seg.tCruise:
+		dAcc := seg.v0*seg.tAcc + 0.5*seg.acc*seg.tAcc*seg.tAcc
+		return dAcc + seg.peak*(tau-seg.tAcc), seg.peak
+	case tau < seg.tAcc+seg.tCruise+seg.tDec:
+		rem := seg.tAcc + seg.tCruise + seg.tDec - tau
+		return seg.length - (seg.v1*rem + 0.5*seg.acc*rem*rem), seg.v1 + seg.acc*rem
+	}
+	return seg.length, seg.v1
+}
+
+// At returns the state of the robot at time t
+func (traj *Trajectory) At(t float32) State {
+	// Find the segment being traversed at time t
+	idx := 0
+	for idx < len(traj.segments)-1 && float64(t) >= traj.segments[idx+1].start {
+		idx++
+	}
+	seg := traj.segments[idx]
+	dist, speed := seg.eval(float64(t) - seg.start)
+
+	return State{
+		T:  t,
+		X:  float32(seg.x0 + seg.ux*dist),
+		Y:  float32(seg.y0 + seg.uy*dist),
+		VX: float32(seg.ux * speed),
+		VY: float32(seg.uy * speed),
+	}
+}
+
+// Sample returns states every dt seconds, always including the final state
+func (traj *Trajectory) Sample(dt float32) []State {
+	var states []State
+	if dt <= 0 {
+		return states
+	}
+	steps := int(math.Ceil(float64(traj.Duration / dt)))
+	for i := 0; i < steps; i++ {
+		states = append(states, traj.At(float32(i)*dt))
+	}
+	return append(states, traj.At(traj.Duration))
+}
@@ -0,0 +1,73 @@
+package trajectory
+
+// Unit testing for trajectory.go. Tests the following functions:
+// New
+// At
+// Sample
+//
+
+import (
+	"math"
+	"proj3-redesigned/configspace"
+	"testing"
+)
+
+// Test New rejects bad input
+func TestNewInvalid(t *testing.T) {
+	pts := []*configspace.Point{{X: 0, Y: 0}, {X: 10, Y: 0}}
+	if _, err := New(pts, Limits{MaxVel: 0, MaxAcc: 1}); err == nil {
+		t.Error("New accepted zero velocity limit")
+	}
+	if _, err := New(pts[:1], Limits{MaxVel: 1, MaxAcc: 1}); err == nil {
+		t.Error("New accepted a single waypoint")
+	}
+}
+
+// Test a long segment reaches cruise speed and a short one does not
+func TestTrapezoidProfile(t *testing.T) {
+	pts := []*configspace.Point{{X: 0, Y: 0}, {X: 100, Y: 0}}
+	traj, _ := New(pts, Limits{MaxVel: 10, MaxAcc: 5})
+	// 2s accelerating, 8s cruising, 2s decelerating
+	if math.Abs(float64(traj.Duration)-12) > 1e-4 {
+		t.Errorf("Expected duration 12, got %v", traj.Duration)
+	}
+	if s := traj.At(6); s.VX != 10 || s.X != 50 {
+		t.Errorf("Expected cruise at x=50 v=10, got %+v", s)
+	}
+
+	pts = []*configspace.Point{{X: 0, Y: 0}, {X: 0, Y: 5}}
+	traj, _ = New(pts, Limits{MaxVel: 10, MaxAcc: 5})
+	if s := traj.At(traj.Duration / 2); s.VY > 10 || s.VY <= 0 {
+		t.Errorf("Expected triangular profile peak below limit, got %+v", s)
+	}
+}
+
+// Test the robot stops at corners unless blending is enabled
+func TestCornerBlend(t *testing.T) {
+	pts := []*configspace.Point{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 100}}
+	stop, _ := New(pts, Limits{MaxVel: 10, MaxAcc: 5})
+	blend, _ := New(pts, Limits{MaxVel: 10, MaxAcc: 5, Blend: 2})
+	if blend.Duration >= stop.Duration {
+		t.Errorf("Expected blended trajectory faster, got %v >= %v",
+			blend.Duration, stop.Duration)
+	}
+	if s := stop.At(float32(stop.segments[1].start)); s.VX != 0 || s.VY != 0 {
+		t.Errorf("Expected stop at corner, got %+v", s)
+	}
+}
+
+// Test samples respect the limits and end at the goal
+func TestSample(t *testing.T) {
+	pts := []*configspace.Point{{X: 0, Y: 0}, {X: 30, Y: 40}, {X: 60, Y: 0}}
+	traj, _ := New(pts, Limits{MaxVel: 10, MaxAcc: 5, Blend: 1})
+	states := traj.Sample(0.1)
+	for _, s := range states {
+		if math.Hypot(float64(s.VX), float64(s.VY)) > 10+1e-3 {
+			t.Errorf("Speed limit exceeded at %+v", s)
+		}
+	}
+	last := states[len(states)-1]
+	if last.X != 60 || last.Y != 0 || last.T != traj.Duration {
+		t.Errorf("Expected final state at goal, got %+v", last)
+	}
+}
@@ -0,0 +1,80 @@
+package viewer
+
+import (
+	"image"
+	"math"
+	"proj3-redesigned/robotpath"
+)
+
+// Width snapshots are rendered at, the viewer zooms into this image
+const snapshotWidth = 1600
+
+// Snapshot is a rendering of a path at some point during planning
+type Snapshot struct {
+	Image   *image.RGBA // Rendered path
+	Samples int         // Samples drawn so far
+	Cost    float32     // Goal cost, zero until the goal is reached
+}
+
+// Feed renders snapshots of a path and sends them to a viewer. Snapshots are
+// dropped while the viewer is behind, so planning never waits on the display.
+// Like the recorder, it must be observed while no updates are running
+type Feed struct {
+	Path     *robotpath.Path         // Path being planned
+	Interval int                     // Samples between snapshots
+	Options  robotpath.RenderOptions // Options snapshots are rendered with
+	C        chan Snapshot           // Snapshots for the viewer
+	next     int                     // Samples at which the next snapshot is due
+}
+
+// Create a new Feed sending a snapshot every interval samples, rendered to fit
+// 1600 pixels wide unless the options give a scale
+func NewFeed(path *robotpath.Path, interval int, opts robotpath.RenderOptions) *Feed {
+	if interval < 1 {
+		interval = 1
+	}
+	if opts.Scale <= 0 {
+		opts.Scale = math.Min(1, snapshotWidth/float64(path.Config.WinWidth+path.Config.WinDepth))
+	}
+	return &Feed{
+		Path:     path,
+		Interval: interval,
+		Options:  opts,
+		C:        make(chan Snapshot, 1),
+		next:     interval,
+	}
+}
+
+// Send a snapshot if a multiple of the interval was reached and the viewer has
+// taken the previous one
+func (f *Feed) Observe(samples int) {
+	if samples < f.next {
+		return
+	}
+	f.next = (samples/f.Interval + 1) * f.Interval
+	if len(f.C) == 0 {
+		f.C <- f.snapshot(samples)
+	}
+}
+
+// Send a final snapshot, replacing any the viewer has not taken, and close the
+// feed
+func (f *Feed) Close(samples int) {
+	select {
+	case <-f.C:
+	default:
+	}
+	f.C <- f.snapshot(samples)
+	close(f.C)
+}
+
+// Render a snapshot of the path
+func (f *Feed) snapshot(samples int) Snapshot {
+	opts := f.Options
+	opts.Samples = samples
+	return Snapshot{
+		Image:   f.Path.Render(opts),
+		Samples: samples,
+		Cost:    f.Path.DistToGoal(),
+	}
+}
@@ -0,0 +1,146 @@
+package viewer
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/fogleman/gg"
+)
+
+// Limits on the zoom relative to fitting the snapshot in the frame
+const (
+	minZoom = 0.5
+	maxZoom = 32
+)
+
+// Background around the snapshot
+var background = color.Gray{Y: 64}
+
+// Viewer shows the latest snapshot from a feed with pan, zoom and pause. It
+// renders frames offscreen, so it runs headless in tests and behind a window
+// when built with ebiten
+type Viewer struct {
+	snapshots <-chan Snapshot
+	current   *Snapshot
+	fit       float64 // Scale that fits the snapshot in the last frame
+	zoom      float64 // Zoom relative to fit
+	centerX   float64 // Snapshot pixel at the center of the frame
+	centerY   float64
+	paused    bool
+	finished  bool
+}
+
+// Create a new Viewer reading snapshots from a channel
+func New(snapshots <-chan Snapshot) *Viewer {
+	return &Viewer{snapshots: snapshots, zoom: 1}
+}
+
+// Take the latest snapshot unless paused, returns true if it changed. Once the
+// feed is closed the last snapshot is kept
+func (v *Viewer) Update() bool {
+	changed := false
+	for !v.paused && !v.finished {
+		select {
+		case snapshot, ok := <-v.snapshots:
+			if !ok {
+				v.finished = true
+				return changed
+			}
+			if v.current == nil {
+				bounds := snapshot.Image.Bounds()
+				v.centerX, v.centerY = float64(bounds.Dx())/2, float64(bounds.Dy())/2
+			}
+			v.current = &snapshot
+			changed = true
+		default:
+			return changed
+		}
+	}
+	return changed
+}
+
+// Pan the view by a distance in frame pixels
+func (v *Viewer) Pan(dx float64, dy float64) {
+	scale := v.scale()
+	v.centerX -= dx / scale
+	v.centerY -= dy / scale
+}
+
+// Zoom the view by a factor, keeping the given frame position fixed
+func (v *Viewer) Zoom(factor float64, x float64, y float64, width int, height int) {
+	before := v.scale()
+	v.zoom = math.Max(minZoom, math.Min(maxZoom, v.zoom*factor))
+	after := v.scale()
+
+	// The snapshot point under (x, y) stays there
+	offX, offY := x-float64(width)/2, y-float64(height)/2
+	v.centerX += offX/before - offX/after
+	v.centerY += offY/before - offY/after
+}
+
+// Pause or resume taking snapshots
+func (v *Viewer) TogglePause() {
+	v.paused = !v.paused
+}
+
+// Check if the viewer is paused
+func (v *Viewer) Paused() bool {
+	return v.paused
+}
+
+// Check if the feed has closed
+func (v *Viewer) Finished() bool {
+	return v.finished
+}
+
+// Get the scale from snapshot pixels to frame pixels
+func (v *Viewer) scale() float64 {
+	if v.fit == 0 {
+		return v.zoom
+	}
+	return v.fit * v.zoom
+}
+
+// Render the current view into a frame of the given size, with a status line
+// at the bottom
+func (v *Viewer) Frame(width int, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	screen := gg.NewContextForRGBA(img)
+	screen.SetColor(background)
+	screen.Clear()
+
+	status := "waiting for samples"
+	if v.current != nil {
+		bounds := v.current.Image.Bounds()
+		v.fit = math.Min(float64(width)/float64(bounds.Dx()), float64(height)/float64(bounds.Dy()))
+		scale := v.scale()
+
+		screen.Push()
+		screen.Translate(float64(width)/2, float64(height)/2)
+		screen.Scale(scale, scale)
+		screen.Translate(-v.centerX, -v.centerY)
+		screen.DrawImage(v.current.Image, 0, 0)
+		screen.Pop()
+
+		goal := "not reached"
+		if v.current.Cost > 0 {
+			goal = fmt.Sprintf("%.1f", v.current.Cost)
+		}
+		status = fmt.Sprintf("%d samples, goal %s, zoom %.1fx", v.current.Samples, goal, v.zoom)
+	}
+	if v.paused {
+		status += ", paused"
+	} else if v.finished {
+		status += ", finished"
+	}
+
+	_, textHeight := screen.MeasureString(status)
+	screen.SetColor(color.Black)
+	screen.DrawRectangle(0, float64(height)-textHeight-10, float64(width), textHeight+10)
+	screen.Fill()
+	screen.SetColor(color.White)
+	screen.DrawString(status, 5, float64(height)-5)
+	return img
+}
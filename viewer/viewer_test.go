@@ -0,0 +1,120 @@
+package viewer
+
+// Unit testing for the viewer and feed. Tests the following functions:
+// Feed Observe
+// Feed Close
+// Viewer Update
+// Viewer Frame
+// Viewer Pan and Zoom
+//
+
+import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"proj3-redesigned/robotpath"
+	"testing"
+)
+
+// Create a path in an empty 200x100 window
+func testPath(t *testing.T) *robotpath.Path {
+	configPath := filepath.Join(t.TempDir(), "open.txt")
+	config := "window,100,200\nvisibility,20\nstart,10,10\ngoal,190,90\n"
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return robotpath.NewPath(configPath)
+}
+
+// Test snapshots are dropped while the viewer is behind and the last one is
+// always delivered
+func TestFeed(t *testing.T) {
+	feed := NewFeed(testPath(t), 10, robotpath.RenderOptions{})
+	if feed.Options.Scale != 1 {
+		t.Errorf("expected scale 1, got %v", feed.Options.Scale)
+	}
+	feed.Observe(5)
+	if len(feed.C) != 0 {
+		t.Error("snapshot sent before the interval")
+	}
+	feed.Observe(10)
+	feed.Observe(20)
+	snapshot := <-feed.C
+	if snapshot.Samples != 10 || snapshot.Image.Bounds().Dx() != 200 {
+		t.Errorf("expected the first snapshot at 10 samples, got %d", snapshot.Samples)
+	}
+
+	feed.Observe(30)
+	feed.Close(35)
+	if snapshot := <-feed.C; snapshot.Samples != 35 {
+		t.Errorf("expected the final snapshot at 35 samples, got %d", snapshot.Samples)
+	}
+	if _, ok := <-feed.C; ok {
+		t.Error("feed was not closed")
+	}
+}
+
+// Test the viewer follows the feed until paused or finished
+func TestViewerUpdate(t *testing.T) {
+	feed := NewFeed(testPath(t), 1, robotpath.RenderOptions{})
+	v := New(feed.C)
+	if v.Update() {
+		t.Error("update without a snapshot")
+	}
+	feed.Observe(1)
+	if !v.Update() || v.current.Samples != 1 {
+		t.Error("snapshot was not taken")
+	}
+
+	v.TogglePause()
+	feed.Observe(2)
+	if v.Update() || v.current.Samples != 1 {
+		t.Error("snapshot taken while paused")
+	}
+	v.TogglePause()
+	feed.Close(3)
+	if !v.Update() || v.current.Samples != 3 || !v.Finished() {
+		t.Errorf("expected the final snapshot, got %d samples", v.current.Samples)
+	}
+}
+
+// Test frames fit the snapshot and follow panning and zooming
+func TestViewerFrame(t *testing.T) {
+	path := testPath(t)
+	feed := NewFeed(path, 1, robotpath.RenderOptions{})
+	v := New(feed.C)
+	if img := v.Frame(40, 30); img.RGBAAt(20, 5) != (color.RGBA{R: 64, G: 64, B: 64, A: 255}) {
+		t.Errorf("expected a blank frame, got %v", img.RGBAAt(20, 5))
+	}
+
+	feed.Observe(1)
+	v.Update()
+
+	// The 200x100 snapshot fits a 400x300 frame at twice its size, centered
+	// with a 50 pixel band above and below
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	img := v.Frame(400, 300)
+	if img.RGBAAt(200, 25) == white || img.RGBAAt(200, 100) != white {
+		t.Error("snapshot was not fit to the frame")
+	}
+
+	// The start point at (10, 10) is drawn at (20, 70)
+	if c := img.RGBAAt(20, 70); c == white {
+		t.Errorf("expected the start point, got %v", c)
+	}
+
+	// Zooming in about the start keeps it in place
+	v.Zoom(4, 20, 70, 400, 300)
+	if c := v.Frame(400, 300).RGBAAt(20, 70); c == white {
+		t.Errorf("expected the start point after zooming, got %v", c)
+	}
+
+	// Panning moves it with the cursor
+	v.Pan(100, 50)
+	if c := v.Frame(400, 300).RGBAAt(120, 120); c == white {
+		t.Errorf("expected the start point after panning, got %v", c)
+	}
+	if c := v.Frame(400, 300).RGBAAt(300, 200); c != white {
+		t.Errorf("expected empty space after panning, got %v", c)
+	}
+}
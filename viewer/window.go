@@ -0,0 +1,87 @@
+//go:build ebiten
+
+package viewer
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Frame pixels panned per tick while an arrow key is held
+const panSpeed = 10
+
+// Zoom factor per mouse wheel step
+const wheelZoom = 1.1
+
+// window runs a Viewer as an ebiten game
+type window struct {
+	viewer        *Viewer
+	width, height int
+	dragX, dragY  int
+	dragging      bool
+}
+
+// Open a window showing the viewer until it is closed. Arrow keys or dragging
+// pan, the mouse wheel zooms, space pauses and escape quits
+func Run(v *Viewer, title string, width int, height int) error {
+	ebiten.SetWindowTitle(title)
+	ebiten.SetWindowSize(width, height)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	return ebiten.RunGame(&window{viewer: v, width: width, height: height})
+}
+
+// Handle input and take the latest snapshot
+func (w *window) Update() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyQ) {
+		return ebiten.Termination
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		w.viewer.TogglePause()
+	}
+
+	// Keyboard panning
+	if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
+		w.viewer.Pan(panSpeed, 0)
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
+		w.viewer.Pan(-panSpeed, 0)
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
+		w.viewer.Pan(0, panSpeed)
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
+		w.viewer.Pan(0, -panSpeed)
+	}
+
+	// Mouse dragging pans and the wheel zooms around the cursor
+	x, y := ebiten.CursorPosition()
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		if w.dragging {
+			w.viewer.Pan(float64(x-w.dragX), float64(y-w.dragY))
+		}
+		w.dragX, w.dragY, w.dragging = x, y, true
+	} else {
+		w.dragging = false
+	}
+	if _, wheel := ebiten.Wheel(); wheel != 0 {
+		factor := wheelZoom
+		if wheel < 0 {
+			factor = 1 / wheelZoom
+		}
+		w.viewer.Zoom(factor, float64(x), float64(y), w.width, w.height)
+	}
+
+	w.viewer.Update()
+	return nil
+}
+
+// Draw the viewer's current frame
+func (w *window) Draw(screen *ebiten.Image) {
+	screen.WritePixels(w.viewer.Frame(w.width, w.height).Pix)
+}
+
+// Match the frame to the window size
+func (w *window) Layout(outsideWidth int, outsideHeight int) (int, int) {
+	w.width, w.height = outsideWidth, outsideHeight
+	return outsideWidth, outsideHeight
+}
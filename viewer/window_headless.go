@@ -0,0 +1,11 @@
+//go:build !ebiten
+
+package viewer
+
+import "errors"
+
+// Windows need ebiten, which is left out of the default build since it needs
+// cgo and X11 headers on Linux. Frames can still be rendered with Frame
+func Run(v *Viewer, title string, width int, height int) error {
+	return errors.New("viewer window unavailable, rebuild with -tags ebiten")
+}